@@ -7,10 +7,84 @@ import "errors"
 
 //A Board represents information about the state
 //of a Go game. Size represents the size of the board,
-//Grid is the storage of Pieces.
+//the Grid method returns its Pieces. KoRule determines
+//which repeated positions are forbidden; it defaults
+//to SimpleKo. If AllowSuicide is true, a move may
+//capture its own chain of two or more stones. Queries
+//don't change the Board, so several goroutines may make
+//them at once, as long as none plays, passes or undoes.
 type Board struct {
-	Size int
-	Grid [][]Piece
+	Size         int
+	KoRule       KoRule
+	AllowSuicide bool
+	//cells holds the color at each index y*Size+x,
+	//adj the indexes adjacent to each index
+	cells []color
+	adj   [][]int
+	//ko is the Point that can't be played next
+	//by the color in koBlack, nil if no ko
+	ko      *Point
+	koBlack bool
+	//Zobrist hash of the position, and
+	//the history of prior positions
+	hash    uint64
+	history []situation
+	//Stones captured by each color
+	blackCaptures int
+	whiteCaptures int
+	//Moves that can be undone and redone
+	undo []record
+	redo []record
+	//Scratch space for the flood fills of set;
+	//read-only queries use pooled scratch instead
+	work scratch
+}
+
+//A color is what's at an index of the Board
+type color uint8
+
+const (
+	empty color = iota
+	blackStone
+	whiteStone
+)
+
+//Returns the color of a stone
+func stone(isBlack bool) color {
+	if isBlack {
+		return blackStone
+	}
+	return whiteStone
+}
+
+//adjacent holds, for each size of Board, the
+//indexes adjacent to each index, skipping borders
+var adjacent [20][][]int
+
+func init() {
+	for size := 4; size <= 19; size++ {
+		adjacent[size] = make([][]int, size*size)
+		//Slice all the tables from one block
+		block := make([]int, 0, 4*size*size)
+		for y := 0; y < size; y++ {
+			for x := 0; x < size; x++ {
+				start := len(block)
+				if y > 0 {
+					block = append(block, (y-1)*size+x)
+				}
+				if y < size-1 {
+					block = append(block, (y+1)*size+x)
+				}
+				if x > 0 {
+					block = append(block, y*size+x-1)
+				}
+				if x < size-1 {
+					block = append(block, y*size+x+1)
+				}
+				adjacent[size][y*size+x] = block[start:len(block):len(block)]
+			}
+		}
+	}
 }
 
 //A Piece represents information about a piece on the
@@ -20,12 +94,21 @@ type Piece struct {
 	Black bool
 	White bool
 	Empty bool
+	X     int
+	Y     int
 	Up    *Piece //y-1
 	Down  *Piece //y+1
 	Left  *Piece //x-1
 	Right *Piece //x+1
 }
 
+//A Point represents a location on the Board,
+//x, y in range from 0 to Board.Size-1
+type Point struct {
+	X int
+	Y int
+}
+
 //Initializes an empty Board
 func (b *Board) Init(size int) (err error) {
 	if size < 4 || size > 19 {
@@ -33,58 +116,88 @@ func (b *Board) Init(size int) (err error) {
 		return
 	}
 	b.Size = size
+	b.ko = nil
+	b.blackCaptures, b.whiteCaptures = 0, 0
+	b.undo, b.redo = nil, nil
+	b.cells = make([]color, size*size)
+	b.adj = adjacent[size]
+	b.resetHistory(true)
+	return
+}
+
+//Returns the Pieces of the Board as a Grid of rows,
+//Grid[y][x], connected via pointers. The Grid is a
+//copy: changing it doesn't change the Board.
+func (b *Board) Grid() (grid [][]Piece) {
 	//Allocate the top-level slice
-	b.Grid = make([][]Piece, size)
-	for i := range b.Grid {
-		//Allocate the intermediate slices
-		b.Grid[i] = make([]Piece, size)
+	grid = make([][]Piece, b.Size)
+	//Allocate the intermediate slices from one block
+	pieces := make([]Piece, b.Size*b.Size)
+	for i := range grid {
+		grid[i] = pieces[i*b.Size : (i+1)*b.Size]
 	}
-	//Set Pieces to Empty, connect them via pointers
-	for y := 0; y < size; y++ {
-		for x := 0; x < size; x++ {
-			up, down, left, right := true, true, true, true
-			b.Grid[y][x].Empty = true
+	//Set Pieces from the cells, connect them via pointers
+	for y := 0; y < b.Size; y++ {
+		for x := 0; x < b.Size; x++ {
+			p := &grid[y][x]
+			c := b.cells[y*b.Size+x]
+			p.Black, p.White, p.Empty = c == blackStone, c == whiteStone, c == empty
+			p.X, p.Y = x, y
 			//If border, don't connect
-			if y == 0 {
-				up = false
-			}
-			if y == size-1 {
-				down = false
-			}
-			if x == 0 {
-				left = false
-			}
-			if x == size-1 {
-				right = false
+			if y > 0 {
+				p.Up = &grid[y-1][x]
 			}
-			if up {
-				b.Grid[y][x].Up = &b.Grid[y-1][x]
+			if y < b.Size-1 {
+				p.Down = &grid[y+1][x]
 			}
-			if down {
-				b.Grid[y][x].Down = &b.Grid[y+1][x]
+			if x > 0 {
+				p.Left = &grid[y][x-1]
 			}
-			if left {
-				b.Grid[y][x].Left = &b.Grid[y][x-1]
-			}
-			if right {
-				b.Grid[y][x].Right = &b.Grid[y][x+1]
+			if x < b.Size-1 {
+				p.Right = &grid[y][x+1]
 			}
 		}
 	}
 	return
 }
 
+//Returns the Piece at x, y, without
+//pointers to adjacent pieces
+func (b *Board) At(x, y int) (p Piece, err error) {
+	if err = b.checkRange(x, y); err != nil {
+		return
+	}
+	c := b.cells[y*b.Size+x]
+	p = Piece{Black: c == blackStone, White: c == whiteStone, Empty: c == empty, X: x, Y: y}
+	return
+}
+
 //Sets a Piece to black on the Board
 //x, y in range from 1 to Board.Size
 func (b *Board) SetB(x, y int) (err error) {
-	err = b.set(x, y, true)
+	_, err = b.set(x, y, true)
 	return
 }
 
 //Sets a Piece to white on the Board
 //x, y in range from 1 to Board.Size
 func (b *Board) SetW(x, y int) (err error) {
-	err = b.set(x, y, false)
+	_, err = b.set(x, y, false)
+	return
+}
+
+//Places a stone of the given color on the Board,
+//returns what it captured and the resulting ko
+//x, y in range from 0 to Board.Size-1
+func (b *Board) Play(isBlack bool, x, y int) (res MoveResult, err error) {
+	res, err = b.set(x, y, isBlack)
+	return
+}
+
+//Records a pass by the given color, which clears
+//any ko. Undo takes it back like any other move.
+func (b *Board) Pass(isBlack bool) {
+	b.pass(isBlack)
 	return
 }
 
@@ -99,11 +212,10 @@ func (b *Board) PrettyString() (str string) {
 	wht := "\u25cf"
 	for y := 0; y < b.Size; y++ {
 		for x := 0; x < b.Size; x++ {
-			p := b.Grid[y][x]
-			switch {
-			case p.Black:
+			switch b.cells[y*b.Size+x] {
+			case blackStone:
 				str += blk
-			case p.White:
+			case whiteStone:
 				str += wht
 			default:
 				str += " "
@@ -1,6 +1,9 @@
 package baduk
 
-import "testing"
+import (
+	"sync"
+	"testing"
+)
 
 func TestInit(t *testing.T) {
 	var b Board
@@ -61,7 +64,7 @@ func TestDecode(t *testing.T) {
 	if b.Size != 4 {
 		t.Error("Expected size to be 4, got", b.Size)
 	}
-	if !(b.Grid[0][0].Black && b.Grid[1][0].Black && b.Grid[1][1].White && b.Grid[2][2].White) {
+	if !(b.Grid()[0][0].Black && b.Grid()[1][0].Black && b.Grid()[1][1].White && b.Grid()[2][2].White) {
 		t.Errorf("Expected different board, got" + b.PrettyString())
 	}
 	return
@@ -74,12 +77,12 @@ func TestHasLiberty(t *testing.T) {
 		t.Error("Error Decoding:", err)
 	}
 	b.SetB(1, 0)
-	if b.Grid[0][0].hasLiberty() {
-		t.Error("Expected false, got true with piece at 0,0", b.Grid[0][0])
+	if b.hasLiberty(0) {
+		t.Error("Expected false, got true with piece at 0,0")
 		t.Logf(b.PrettyString())
 	}
-	if !b.Grid[1][1].hasLiberty() {
-		t.Error("Expected false, got true with piece at 1,1", b.Grid[1][1])
+	if !b.hasLiberty(1*b.Size + 1) {
+		t.Error("Expected false, got true with piece at 1,1")
 		t.Logf(b.PrettyString())
 	}
 	return
@@ -169,3 +172,335 @@ func TestScore(t *testing.T) {
 		t.Errorf(b.PrettyString())
 	}
 }
+
+func TestKo(t *testing.T) {
+	var b Board
+	b.Init(5)
+	//Classic ko shape, white stone at 1,1 in atari
+	b.SetB(1, 0)
+	b.SetB(0, 1)
+	b.SetB(1, 2)
+	b.SetW(2, 0)
+	b.SetW(3, 1)
+	b.SetW(2, 2)
+	b.SetW(1, 1)
+	if err := b.SetB(2, 1); err != nil {
+		t.Error("Error taking ko:", err)
+	}
+	if !b.Grid()[1][1].Empty {
+		t.Error("Expected white stone at 1,1 to be captured")
+		t.Log(b.PrettyString())
+	}
+	//Immediate retake is forbidden, and the board is unchanged
+	if err := b.SetW(1, 1); err != ErrKo {
+		t.Error("Expected ErrKo, got", err)
+	}
+	if !b.Grid()[1][1].Empty || !b.Grid()[1][2].Black {
+		t.Error("Expected board unchanged after ko violation")
+		t.Log(b.PrettyString())
+	}
+	//After an exchange elsewhere, white may retake
+	b.SetW(4, 4)
+	b.SetB(0, 4)
+	if err := b.SetW(1, 1); err != nil {
+		t.Error("Error retaking ko:", err)
+	}
+	if !b.Grid()[1][2].Empty {
+		t.Error("Expected black stone at 2,1 to be captured")
+		t.Log(b.PrettyString())
+	}
+	//Now black can't immediately retake
+	if err := b.SetB(2, 1); err != ErrKo {
+		t.Error("Expected ErrKo, got", err)
+	}
+}
+
+func TestSnapback(t *testing.T) {
+	var b Board
+	b.Init(5)
+	b.SetW(2, 0)
+	b.SetW(0, 1)
+	b.SetW(1, 1)
+	b.SetW(2, 1)
+	b.SetB(3, 0)
+	b.SetB(3, 1)
+	b.SetB(0, 2)
+	b.SetB(1, 2)
+	b.SetB(2, 2)
+	//Black throws in, white captures the single stone
+	b.SetB(1, 0)
+	if err := b.SetW(0, 0); err != nil {
+		t.Error("Error capturing throw-in:", err)
+	}
+	if !b.Grid()[0][1].Empty {
+		t.Error("Expected black stone at 1,0 to be captured")
+		t.Log(b.PrettyString())
+	}
+	//Black retakes at once, capturing the whole chain
+	if err := b.SetB(1, 0); err != nil {
+		t.Error("Expected snapback to be legal, got", err)
+	}
+	for _, p := range []Point{{0, 0}, {2, 0}, {0, 1}, {1, 1}, {2, 1}} {
+		if !b.Grid()[p.Y][p.X].Empty {
+			t.Error("Expected white stone at", p, "to be captured")
+		}
+	}
+}
+
+func TestSuicide(t *testing.T) {
+	var b Board
+	b.Init(5)
+	b.SetB(0, 0)
+	b.SetW(0, 1)
+	b.SetW(1, 1)
+	b.SetW(2, 0)
+	b.SetW(3, 4)
+	b.SetW(4, 3)
+	//Single stone suicide is forbidden
+	if err := b.SetB(4, 4); err != ErrSuicide {
+		t.Error("Expected ErrSuicide, got", err)
+	}
+	if !b.Grid()[4][4].Empty {
+		t.Error("Expected 4,4 to remain empty")
+	}
+	//Multi-stone suicide is forbidden by default
+	if err := b.SetB(1, 0); err != ErrSuicide {
+		t.Error("Expected ErrSuicide, got", err)
+	}
+	if !b.Grid()[0][1].Empty || !b.Grid()[0][0].Black {
+		t.Error("Expected board unchanged after suicide")
+		t.Log(b.PrettyString())
+	}
+	//Allowing suicide still forbids single stones
+	b.AllowSuicide = true
+	if err := b.SetB(4, 4); err != ErrSuicide {
+		t.Error("Expected ErrSuicide, got", err)
+	}
+	//...but multi-stone suicide removes the chain
+	res, err := b.Play(true, 1, 0)
+	if err != nil {
+		t.Error("Expected multi-stone suicide to be allowed, got", err)
+	}
+	if !res.SelfCapture || len(res.Points()) != 2 || !b.Grid()[0][0].Empty || !b.Grid()[0][1].Empty {
+		t.Error("Expected black chain to be removed, got", res)
+		t.Log(b.PrettyString())
+	}
+}
+
+func TestScoreKomi(t *testing.T) {
+	var b Board
+	b.Init(4)
+	b.SetB(0, 0)
+	b.SetW(2, 1)
+	b.SetB(1, 1)
+	b.SetW(3, 3)
+	b.SetB(0, 2)
+	//Black 4, white 2, as in TestScore
+	r := b.ScoreKomi(6.5)
+	if r.Black != 4 || r.White != 2 || r.Winner != "W" || r.Margin != 4.5 {
+		t.Error("Expected W+4.5, got", r)
+	}
+	if r.String() != "W+4.5" {
+		t.Error("Expected W+4.5, got", r.String())
+	}
+	r = b.ScoreKomi(0.5)
+	if r.String() != "B+1.5" {
+		t.Error("Expected B+1.5, got", r.String())
+	}
+	r = b.ScoreKomi(2)
+	if r.Winner != "" || r.String() != "0" {
+		t.Error("Expected tie, got", r.String())
+	}
+}
+
+func TestScoreTerritory(t *testing.T) {
+	var b Board
+	b.Init(5)
+	//Black wall on column 1, white wall on column 3,
+	//white captures a black stone at 4,0
+	for y := 0; y < 5; y++ {
+		b.SetB(1, y)
+		b.SetW(3, y)
+	}
+	b.SetB(4, 0)
+	b.SetW(4, 1)
+	black, white := b.Captures()
+	if black != 0 || white != 1 {
+		t.Error("Expected captures black: 0, white: 1, got black:", black, ", white:", white)
+	}
+	//Column 2 is neutral, column 0 is black's, column 4 is white's
+	black, white = b.ScoreTerritory(nil)
+	if black != 5 || white != 4+1 {
+		t.Error("Expected territory black: 5, white: 5, got black:", black, ", white:", white)
+		t.Log(b.PrettyString())
+	}
+	//A dead white stone in black's territory counts as a prisoner
+	b.SetW(0, 2)
+	black, white = b.ScoreTerritory([]Point{{0, 2}})
+	if black != 5+1 || white != 5 {
+		t.Error("Expected territory black: 6, white: 5, got black:", black, ", white:", white)
+		t.Log(b.PrettyString())
+	}
+	if !b.Grid()[2][0].White {
+		t.Error("Expected dead stone to be restored after scoring")
+	}
+}
+
+func TestPlayResult(t *testing.T) {
+	var b Board
+	b.Init(5)
+	//Two white stones, each with a last liberty at 2,2
+	b.SetW(2, 1)
+	b.SetW(2, 3)
+	for _, p := range []Point{{1, 1}, {3, 1}, {2, 0}, {1, 3}, {3, 3}, {2, 4}} {
+		b.SetB(p.X, p.Y)
+	}
+	res, err := b.Play(true, 2, 2)
+	if err != nil {
+		t.Fatal("Error playing:", err)
+	}
+	if len(res.Captured) != 2 || res.Captured[0][0] != (Point{2, 1}) || res.Captured[1][0] != (Point{2, 3}) {
+		t.Error("Expected two captured chains, got", res.Captured)
+	}
+	if res.SelfCapture || res.Ko != nil {
+		t.Error("Expected no self-capture or ko, got", res)
+	}
+	//Taking a ko reports the ko point
+	b.Init(5)
+	setupKo(&b)
+	res, _ = b.Play(true, 2, 1)
+	if res.Ko == nil || *res.Ko != (Point{1, 1}) {
+		t.Error("Expected ko at 1,1, got", res.Ko)
+	}
+	if pts := res.Points(); len(pts) != 1 || pts[0] != (Point{1, 1}) {
+		t.Error("Expected capture at 1,1, got", pts)
+	}
+}
+
+func TestMarkWrap(t *testing.T) {
+	var b Board
+	playout(&b, 250)
+	black, white := b.Score()
+	//Scores must survive the mark epoch wrapping around
+	var s scratch
+	s.newMark(len(b.cells))
+	s.epoch = ^uint32(0) - 1
+	for i := 0; i < 3; i++ {
+		if bl, wh := b.score(&s, nil); bl != black || wh != white {
+			t.Error("Expected", black, white, "got", bl, wh)
+		}
+	}
+}
+
+func TestConcurrentReads(t *testing.T) {
+	var b Board
+	playout(&b, 250)
+	black, white := b.Score()
+	seki := len(b.Seki())
+	dead := b.Groups(true)[0].Stones
+	db, dw := b.ScoreTerritory(dead)
+	legal := len(b.LegalMoves(false))
+	before, _ := b.Encode()
+	g := Game{dead: map[Point]bool{dead[0]: true}}
+	g.Board.CopyFrom(&b)
+	//Queries don't write to the Board, not even
+	//scratch space, so several goroutines may run them
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if bl, wh := b.Score(); bl != black || wh != white {
+				t.Error("Expected", black, white, "got", bl, wh)
+			}
+			if len(b.Seki()) != seki {
+				t.Error("Expected", seki, "chains in seki")
+			}
+			if bl, wh := b.ScoreTerritory(dead); bl != db || wh != dw {
+				t.Error("Expected", db, dw, "got", bl, wh)
+			}
+			if len(b.LegalMoves(false)) != legal {
+				t.Error("Expected", legal, "legal moves")
+			}
+			b.ScoreArea(dead)
+			b.Groups(true)
+			b.SelfAtari(true, 9, 9)
+			b.Ladder(dead[0].X, dead[0].Y)
+			g.Seki()
+		}()
+	}
+	wg.Wait()
+	if after, _ := b.Encode(); after != before {
+		t.Error("Expected the Board unchanged by queries")
+	}
+}
+
+//Plays a fixed pseudorandom game of up to
+//moves moves on a 19x19 Board
+func playout(b *Board, moves int) {
+	b.Init(19)
+	seed := uint32(1)
+	black := true
+	for i := 0; i < moves; i++ {
+		//Try a few points, pass if none are legal
+		for try := 0; try < 10; try++ {
+			seed = seed*1664525 + 1013904223
+			x, y := int(seed>>8)%19, int(seed>>20)%19
+			if _, err := b.set(x, y, black); err == nil {
+				break
+			}
+		}
+		black = !black
+	}
+	return
+}
+
+func BenchmarkSet(b *testing.B) {
+	var c Board
+	for i := 0; i < b.N; i++ {
+		playout(&c, 250)
+	}
+}
+
+func BenchmarkSetCapture(b *testing.B) {
+	var c Board
+	for i := 0; i < b.N; i++ {
+		c.Init(19)
+		//White fills the top two rows, black
+		//captures them by filling the third
+		for x := 0; x < 19; x++ {
+			c.SetW(x, 0)
+			c.SetW(x, 1)
+		}
+		for x := 0; x < 19; x++ {
+			c.SetB(x, 2)
+		}
+	}
+}
+
+func BenchmarkScore(b *testing.B) {
+	var c Board
+	playout(&c, 250)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Score()
+	}
+}
+
+func BenchmarkClone(b *testing.B) {
+	var c Board
+	playout(&c, 250)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.Clone()
+	}
+}
+
+func BenchmarkCopyFrom(b *testing.B) {
+	var c, d Board
+	playout(&c, 250)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.CopyFrom(&c)
+	}
+}
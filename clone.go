@@ -0,0 +1,37 @@
+package baduk
+
+//Returns a deep copy of the Board. The copy has
+//its own stones, and its own history for ko and undo.
+func (b *Board) Clone() (c *Board) {
+	c = new(Board)
+	c.CopyFrom(b)
+	return
+}
+
+//Makes the Board a deep copy of another, as Clone
+//does. If the Board is already the same size, its
+//storage is reused, so copying allocates nothing.
+func (b *Board) CopyFrom(o *Board) {
+	if b == o {
+		return
+	}
+	if b.Size != o.Size || len(b.cells) != len(o.cells) {
+		b.Init(o.Size)
+	}
+	copy(b.cells, o.cells)
+	b.KoRule = o.KoRule
+	b.AllowSuicide = o.AllowSuicide
+	b.ko, b.koBlack = nil, o.koBlack
+	if o.ko != nil {
+		ko := *o.ko
+		b.ko = &ko
+	}
+	b.hash = o.hash
+	b.history = append(b.history[:0], o.history...)
+	b.blackCaptures, b.whiteCaptures = o.blackCaptures, o.whiteCaptures
+	//Records are never changed once made, so
+	//copying the stacks is enough
+	b.undo = append(b.undo[:0], o.undo...)
+	b.redo = append(b.redo[:0], o.redo...)
+	return
+}
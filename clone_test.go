@@ -0,0 +1,87 @@
+package baduk
+
+import "testing"
+
+func TestClone(t *testing.T) {
+	var b Board
+	b.Init(5)
+	b.KoRule = SituationalSuperko
+	setupKo(&b)
+	b.SetB(2, 1)
+	c := b.Clone()
+	//Neighbors link within the Grid
+	grid := c.Grid()
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			p := &grid[y][x]
+			if (p.Up != nil && p.Up != &grid[y-1][x]) || (p.Right != nil && p.Right != &grid[y][x+1]) {
+				t.Fatal("Expected Grid neighbors to link within the Grid at", x, y)
+			}
+		}
+	}
+	if c.KoRule != SituationalSuperko || c.hash != b.hash || c.ko == nil || *c.ko != *b.ko {
+		t.Error("Expected clone to keep rules, hash and ko")
+	}
+	//Ko carries over, and captures in the clone don't touch the original
+	if err := c.SetW(1, 1); err != ErrKo {
+		t.Error("Expected ErrKo in clone, got", err)
+	}
+	c.SetW(1, 3)
+	c.SetB(4, 4)
+	c.SetW(1, 1)
+	if !c.Grid()[1][2].Empty || !b.Grid()[1][2].Black {
+		t.Error("Expected capture in clone only")
+		t.Log(b.PrettyString())
+	}
+	if b.MoveNumber() != 8 || c.MoveNumber() != 11 {
+		t.Error("Expected separate histories, got", b.MoveNumber(), c.MoveNumber())
+	}
+	//Undo in the clone restores its own stones
+	c.Undo()
+	if !c.Grid()[1][2].Black || !c.Grid()[1][1].Empty {
+		t.Error("Expected undo in clone to restore capture")
+		t.Log(c.PrettyString())
+	}
+}
+
+func TestCopyFrom(t *testing.T) {
+	var b, c Board
+	b.Init(9)
+	b.SetB(4, 4)
+	c.Init(9)
+	c.SetW(0, 0)
+	first := &c.cells[0]
+	c.CopyFrom(&b)
+	if &c.cells[0] != first {
+		t.Error("Expected CopyFrom to reuse the storage of the same size")
+	}
+	if grid := c.Grid(); !grid[0][0].Empty || !grid[4][4].Black {
+		t.Error("Expected copied stones")
+	}
+	//Different sizes are reallocated
+	c.Init(13)
+	c.CopyFrom(&b)
+	if c.Size != 9 || len(c.Grid()) != 9 || !c.Grid()[4][4].Black {
+		t.Error("Expected 9x9 copy, got size", c.Size)
+	}
+	c.SetW(0, 0)
+	if !b.Grid()[0][0].Empty {
+		t.Error("Expected original unchanged by copy")
+	}
+}
+
+func TestAt(t *testing.T) {
+	var b Board
+	b.Init(5)
+	b.SetW(3, 1)
+	p, err := b.At(3, 1)
+	if err != nil || !p.White || p.Empty || p.X != 3 || p.Y != 1 {
+		t.Error("Expected white Piece at 3,1, got", p, err)
+	}
+	if g := b.Grid(); !g[1][3].White || !g[1][2].Right.White {
+		t.Error("Expected Grid to match At")
+	}
+	if _, err = b.At(5, 0); err != ErrOutOfRange {
+		t.Error("Expected ErrOutOfRange, got", err)
+	}
+}
@@ -0,0 +1,45 @@
+//Command baduk-gtp speaks the Go Text Protocol on standard
+//input and output, playing on a baduk.Board, so it can be
+//plugged into GoGui, Sabaki or other GTP controllers.
+//Its genmove plays random legal moves that don't fill
+//its own eyes.
+//
+//Usage:
+//
+//	baduk-gtp [-rules name] [-seed n]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"time"
+
+	"github.com/acityinohio/baduk"
+	"github.com/acityinohio/baduk/gtp"
+)
+
+func main() {
+	rules := flag.String("rules", "Chinese", "ruleset: Chinese, Japanese, AGA, NZ, Ing or Tromp-Taylor")
+	seed := flag.Int64("seed", 0, "seed for genmove, 0 seeds from the time")
+	flag.Parse()
+	r, ok := baduk.RulesByName(*rules)
+	if !ok {
+		fmt.Fprintln(os.Stderr, "baduk-gtp: unknown rules", *rules)
+		os.Exit(2)
+	}
+	if *seed == 0 {
+		*seed = time.Now().UnixNano()
+	}
+	e := gtp.Engine{
+		Name:  "baduk",
+		Rules: r,
+		Rand:  rand.New(rand.NewSource(*seed)),
+	}
+	if err := e.Serve(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "baduk-gtp:", err)
+		os.Exit(1)
+	}
+	return
+}
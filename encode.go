@@ -19,16 +19,14 @@ func (b *Board) Encode() (enc string, err error) {
 	//use flate to compress
 	dict := []byte{2, 1, 0}
 	w, err := flate.NewWriterDict(&a, flate.BestCompression, dict)
-	for _, v := range b.Grid {
-		for _, s := range v {
-			switch {
-			case s.Black:
-				w.Write(dict[0:1])
-			case s.White:
-				w.Write(dict[1:2])
-			default:
-				w.Write(dict[2:3])
-			}
+	for _, c := range b.cells {
+		switch c {
+		case blackStone:
+			w.Write(dict[0:1])
+		case whiteStone:
+			w.Write(dict[1:2])
+		default:
+			w.Write(dict[2:3])
 		}
 	}
 	w.Close()
@@ -62,13 +60,11 @@ func (b *Board) Decode(str string) (err error) {
 			}
 			switch c {
 			case dict[0]:
-				b.Grid[y][x].Black = true
-				b.Grid[y][x].Empty = false
+				b.cells[y*size+x] = blackStone
 			case dict[1]:
-				b.Grid[y][x].White = true
-				b.Grid[y][x].Empty = false
+				b.cells[y*size+x] = whiteStone
 			case dict[2]:
-				b.Grid[y][x].Empty = true
+				b.cells[y*size+x] = empty
 			default:
 				err = errors.New("Piece not recognized during decode")
 			}
@@ -78,5 +74,6 @@ func (b *Board) Decode(str string) (err error) {
 		}
 	}
 	r.Close()
+	b.resetHistory(true)
 	return
 }
@@ -0,0 +1,192 @@
+package baduk
+
+import "sync"
+
+//Scratch space for flood fills: marks holds the
+//epoch each index was last marked in, stack the
+//indexes being crawled
+type scratch struct {
+	marks []uint32
+	epoch uint32
+	stack []int
+}
+
+//Scratch for read-only queries, so several
+//goroutines can read the same Board at once
+var scratchPool = sync.Pool{
+	New: func() interface{} { return new(scratch) },
+}
+
+//Gets scratch from the pool, return it with putScratch
+func getScratch() *scratch {
+	return scratchPool.Get().(*scratch)
+}
+
+//Returns scratch to the pool
+func putScratch(s *scratch) {
+	scratchPool.Put(s)
+	return
+}
+
+//Starts a new epoch for marking n indexes, so
+//marks from earlier flood fills no longer count
+func (s *scratch) newMark(n int) {
+	if len(s.marks) != n {
+		s.marks = make([]uint32, n)
+		s.epoch = 0
+	}
+	s.epoch++
+	//On wrap around, old marks could collide
+	if s.epoch == 0 {
+		for i := range s.marks {
+			s.marks[i] = 0
+		}
+		s.epoch = 1
+	}
+	return
+}
+
+//Returns true if index is marked in this epoch
+func (s *scratch) marked(i int) bool {
+	return s.marks[i] == s.epoch
+}
+
+//Marks an index, returns false if it
+//was already marked in this epoch
+func (s *scratch) setMark(i int) bool {
+	if s.marks[i] == s.epoch {
+		return false
+	}
+	s.marks[i] = s.epoch
+	return true
+}
+
+//Crawls the chain of stones containing index i,
+//appending its indexes to buf, and returns it and
+//whether it has any liberties. If stopAtLiberty is
+//true, the crawl stops at the first liberty found,
+//and the chain returned is incomplete.
+func (b *Board) crawlChain(s *scratch, i int, stopAtLiberty bool, buf []int) (chain []int, lib bool) {
+	s.newMark(len(b.cells))
+	s.setMark(i)
+	c := b.cells[i]
+	chain = append(buf, i)
+	//The chain itself is the queue of indexes to visit
+	for k := len(buf); k < len(chain); k++ {
+		for _, n := range b.adj[chain[k]] {
+			switch {
+			case b.cells[n] == empty:
+				lib = true
+				if stopAtLiberty {
+					return
+				}
+			case b.cells[n] == c && s.setMark(n):
+				chain = append(chain, n)
+			}
+		}
+	}
+	return
+}
+
+//Crawls the whole chain, regardless of liberties
+func (b *Board) wholeChain(i int) (chain []int) {
+	s := getScratch()
+	chain, _ = b.crawlChain(s, i, false, nil)
+	putScratch(s)
+	return
+}
+
+//Returns the liberties of a chain, each once.
+//Stones in the dead mask count as liberties.
+func (b *Board) liberties(chain []int, dead []bool) (libs []int) {
+	s := getScratch()
+	s.newMark(len(b.cells))
+	for _, i := range chain {
+		for _, n := range b.adj[i] {
+			if b.colorAt(n, dead) == empty && s.setMark(n) {
+				libs = append(libs, n)
+			}
+		}
+	}
+	putScratch(s)
+	return
+}
+
+//Crawls the region of empty indexes containing i,
+//appending them to buf, and returns it and whether
+//black or white stones border it. Indexes are marked
+//in the current epoch of s, so several regions can
+//be crawled after one call to newMark. Stones in the
+//dead mask are crawled as if empty.
+func (b *Board) crawlEmpty(s *scratch, i int, buf []int, dead []bool) (region []int, bBord, wBord bool) {
+	s.setMark(i)
+	region = append(buf, i)
+	for k := len(buf); k < len(region); k++ {
+		for _, n := range b.adj[region[k]] {
+			switch b.colorAt(n, dead) {
+			case blackStone:
+				bBord = true
+			case whiteStone:
+				wBord = true
+			default:
+				if s.setMark(n) {
+					region = append(region, n)
+				}
+			}
+		}
+	}
+	return
+}
+
+//Returns the color at index i, or empty if
+//the stone there is in the dead mask. A nil
+//mask has no dead stones.
+func (b *Board) colorAt(i int, dead []bool) color {
+	if dead != nil && dead[i] {
+		return empty
+	}
+	return b.cells[i]
+}
+
+//Makes a dead mask of the stones at Points,
+//or nil if there are none
+func (b *Board) deadMask(pts []Point) (dead []bool) {
+	for _, p := range pts {
+		if b.checkRange(p.X, p.Y) != nil {
+			continue
+		}
+		i := p.Y*b.Size + p.X
+		if b.cells[i] == empty {
+			continue
+		}
+		if dead == nil {
+			dead = make([]bool, len(b.cells))
+		}
+		dead[i] = true
+	}
+	return
+}
+
+//Empties chain, returns the sorted Points emptied
+func (b *Board) emptyChain(chain []int) (emptied []Point) {
+	for _, i := range chain {
+		b.cells[i] = empty
+	}
+	emptied = b.chainPoints(chain)
+	return
+}
+
+//Returns the sorted Points of a chain
+func (b *Board) chainPoints(chain []int) (pts []Point) {
+	pts = make([]Point, 0, len(chain))
+	for _, i := range chain {
+		pts = append(pts, b.point(i))
+	}
+	sortPoints(pts)
+	return
+}
+
+//Returns the Point at index i
+func (b *Board) point(i int) Point {
+	return Point{i % b.Size, i / b.Size}
+}
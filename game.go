@@ -0,0 +1,265 @@
+package baduk
+
+import "errors"
+
+//ErrGameOver is returned when a move is attempted
+//after the Game has ended
+var ErrGameOver = errors.New("Game is over")
+
+//ErrNotOver is returned when dead stones are
+//marked before the Game has ended
+var ErrNotOver = errors.New("Game is not over")
+
+//A Game wraps a Board, enforcing turn order
+//and recording the history of moves.
+//Black plays first, then turns alternate,
+//unless Black has a handicap, when White plays first.
+//The Game ends after two consecutive passes,
+//then players mark dead stones and agree to the score.
+//Handicap holds the Points of handicap stones.
+//Rules are applied to the Board by Init,
+//and the zero value plays with SimpleKo,
+//no suicide and AreaScoring. Komi is set
+//from the Rules by Init, and by SetHandicap
+//and FreeHandicap, and may be changed after.
+//Info holds the player names and recorded result.
+type Game struct {
+	Board       Board
+	Moves       []Move
+	Rules       Rules
+	Komi        float64
+	Handicap    []Point
+	Info        GameInfo
+	whiteToPlay bool
+	passes      int
+	dead        map[Point]bool
+	agreed      [2]bool
+	//handicap stones left to place freely
+	freeHandicap int
+	//moves taken back by Undo
+	undone []Move
+}
+
+//A Move represents a single turn in a Game.
+//If Pass is true, X and Y are meaningless.
+//MoveResult holds the chains the move captured
+//and the resulting ko. Comment is kept with
+//the move in SGF.
+type Move struct {
+	Black   bool
+	Pass    bool
+	X       int
+	Y       int
+	Comment string
+	MoveResult
+}
+
+//Initializes a new Game with an empty Board,
+//using the ko and suicide rules and komi in Game.Rules
+func (g *Game) Init(size int) (err error) {
+	if err = g.Board.Init(size); err != nil {
+		return
+	}
+	g.Rules.apply(&g.Board)
+	g.Komi = g.Rules.Komi
+	g.Moves = nil
+	g.whiteToPlay = false
+	g.passes = 0
+	g.dead = nil
+	g.agreed = [2]bool{}
+	g.Handicap = nil
+	g.freeHandicap = 0
+	g.undone = nil
+	return
+}
+
+//Returns true if it's Black's turn to play
+func (g *Game) BlackToPlay() bool {
+	return !g.whiteToPlay
+}
+
+//Returns true if the Game has ended.
+//With PassStones, White must make the last pass.
+func (g *Game) Over() bool {
+	if g.passes < 2 {
+		return false
+	}
+	return !g.Rules.PassStones || !g.Moves[len(g.Moves)-1].Black
+}
+
+//Places a stone for the player whose turn it is
+//x, y in range from 0 to Board.Size-1
+func (g *Game) Play(x, y int) (err error) {
+	if g.Over() {
+		err = ErrGameOver
+		return
+	}
+	if g.freeHandicap > 0 {
+		err = g.placeFree(x, y)
+		return
+	}
+	err = g.play(g.BlackToPlay(), x, y)
+	return
+}
+
+//Places a stone of the given color and records
+//the Move, the other color plays next
+func (g *Game) play(black bool, x, y int) (err error) {
+	res, err := g.Board.set(x, y, black)
+	if err != nil {
+		return
+	}
+	g.Moves = append(g.Moves, Move{Black: black, X: x, Y: y, MoveResult: res})
+	g.undone = nil
+	g.passes = 0
+	g.whiteToPlay = black
+	return
+}
+
+//Passes for the player whose turn it is
+func (g *Game) Pass() (err error) {
+	if g.Over() {
+		err = ErrGameOver
+		return
+	}
+	if g.freeHandicap > 0 {
+		err = errors.New("Handicap stones must be placed first")
+		return
+	}
+	g.pass(g.BlackToPlay())
+	return
+}
+
+//Records a pass by the given color,
+//the other color plays next
+func (g *Game) pass(black bool) {
+	g.Board.pass(black)
+	g.Moves = append(g.Moves, Move{Black: black, Pass: true})
+	g.undone = nil
+	g.passes++
+	g.whiteToPlay = black
+	return
+}
+
+//Returns the number of prisoners taken by each color,
+//as Board.Captures does, plus pass stones if
+//Game.Rules calls for them
+func (g *Game) Prisoners() (black, white int) {
+	black, white = g.Board.Captures()
+	pb, pw := g.passStones()
+	black += pb
+	white += pw
+	return
+}
+
+//Returns the pass stones handed to each color,
+//zero unless Game.Rules calls for them
+func (g *Game) passStones() (black, white int) {
+	if !g.Rules.PassStones {
+		return
+	}
+	for _, m := range g.Moves {
+		switch {
+		case m.Pass && m.Black:
+			white++
+		case m.Pass:
+			black++
+		}
+	}
+	return
+}
+
+//Toggles the chain at x, y between dead and alive,
+//once the Game is over. Any agreement to the score
+//is withdrawn, since the dead stones changed.
+func (g *Game) ToggleDead(x, y int) (err error) {
+	if !g.Over() {
+		err = ErrNotOver
+		return
+	}
+	if err = g.Board.checkRange(x, y); err != nil {
+		return
+	}
+	i := y*g.Board.Size + x
+	if g.Board.cells[i] == empty {
+		err = ErrEmpty
+		return
+	}
+	if g.dead == nil {
+		g.dead = make(map[Point]bool)
+	}
+	dead := !g.dead[Point{x, y}]
+	for _, c := range g.Board.wholeChain(i) {
+		if dead {
+			g.dead[g.Board.point(c)] = true
+		} else {
+			delete(g.dead, g.Board.point(c))
+		}
+	}
+	g.agreed = [2]bool{}
+	return
+}
+
+//Returns the Points of stones marked dead
+func (g *Game) Dead() (dead []Point) {
+	for p := range g.dead {
+		dead = append(dead, p)
+	}
+	sortPoints(dead)
+	return
+}
+
+//Finds chains in seki as Board.Seki does,
+//with stones marked dead counted as empty
+func (g *Game) Seki() (chains [][]Point) {
+	chains = g.Board.seki(g.Board.deadMask(g.Dead()))
+	return
+}
+
+//Agrees to the dead stones as marked for
+//the given color, once the Game is over
+func (g *Game) Agree(black bool) (err error) {
+	if !g.Over() {
+		err = ErrNotOver
+		return
+	}
+	if black {
+		g.agreed[0] = true
+	} else {
+		g.agreed[1] = true
+	}
+	return
+}
+
+//Returns true if both players have agreed
+//to the dead stones, making the score final
+func (g *Game) Agreed() bool {
+	return g.agreed[0] && g.agreed[1]
+}
+
+//Scores the Game according to Game.Rules.Scoring,
+//without komi, treating stones marked dead as captured.
+//Area scoring is the same as Board.ScoreArea, territory
+//scoring is the same as Board.ScoreTerritory plus any
+//pass stones.
+func (g *Game) Score() (black, white int) {
+	dead := g.Dead()
+	switch g.Rules.Scoring {
+	case TerritoryScoring:
+		black, white = g.Board.ScoreTerritory(dead)
+		pb, pw := g.passStones()
+		black += pb
+		white += pw
+	default:
+		black, white = g.Board.ScoreArea(dead)
+	}
+	return
+}
+
+//Scores the Game as Score does, then adds Game.Komi
+//and any handicap compensation for White to make a Result
+func (g *Game) Result() Result {
+	black, white := g.Score()
+	komi := g.Komi + float64(g.Rules.HandicapCompensation(len(g.Handicap)))
+	return NewResult(black, white, komi)
+}
@@ -0,0 +1,154 @@
+package baduk
+
+import "testing"
+
+func TestGameTurns(t *testing.T) {
+	var g Game
+	if err := g.Init(3); err == nil {
+		t.Error("Expected error for small board, got nil")
+	}
+	g.Init(9)
+	if !g.BlackToPlay() {
+		t.Error("Expected Black to play first")
+	}
+	if err := g.Play(2, 2); err != nil {
+		t.Error("Error playing:", err)
+	}
+	if !g.Board.Grid()[2][2].Black {
+		t.Error("Expected black stone at 2,2")
+	}
+	if g.BlackToPlay() {
+		t.Error("Expected White to play second")
+	}
+	//Occupied point shouldn't change the turn
+	if err := g.Play(2, 2); err == nil {
+		t.Error("Expected error playing on occupied point")
+	}
+	if g.BlackToPlay() {
+		t.Error("Expected White to play after failed move")
+	}
+	g.Play(3, 3)
+	if !g.Board.Grid()[3][3].White {
+		t.Error("Expected white stone at 3,3")
+	}
+	if len(g.Moves) != 2 {
+		t.Error("Expected 2 moves, got", len(g.Moves))
+	}
+}
+
+func TestGameCaptures(t *testing.T) {
+	var g Game
+	g.Init(9)
+	//Black surrounds a white stone at 1,0
+	moves := []Point{{0, 0}, {1, 0}, {5, 5}, {6, 6}, {2, 0}, {7, 7}, {1, 1}}
+	for _, m := range moves {
+		if err := g.Play(m.X, m.Y); err != nil {
+			t.Fatal("Error playing", m, ":", err)
+		}
+	}
+	last := g.Moves[len(g.Moves)-1]
+	if len(last.Captured) != 1 || last.Captured[0][0] != (Point{1, 0}) {
+		t.Error("Expected capture of 1,0, got", last.Captured)
+	}
+	if !g.Board.Grid()[0][1].Empty {
+		t.Error("Expected 1,0 to be empty after capture")
+	}
+}
+
+func TestGamePass(t *testing.T) {
+	var g Game
+	g.Init(9)
+	g.Play(4, 4)
+	g.Pass()
+	if !g.BlackToPlay() {
+		t.Error("Expected Black to play after White passes")
+	}
+	//A move in between resets the passes
+	g.Play(3, 3)
+	g.Pass()
+	if g.Over() {
+		t.Error("Expected game to continue after one pass")
+	}
+	g.Pass()
+	if !g.Over() {
+		t.Error("Expected game over after two consecutive passes")
+	}
+	if err := g.Play(0, 0); err != ErrGameOver {
+		t.Error("Expected ErrGameOver, got", err)
+	}
+	if err := g.Pass(); err != ErrGameOver {
+		t.Error("Expected ErrGameOver, got", err)
+	}
+	if len(g.Moves) != 5 || !g.Moves[4].Pass {
+		t.Error("Expected 5 moves ending in a pass, got", g.Moves)
+	}
+}
+
+func TestGameResult(t *testing.T) {
+	var g Game
+	g.Rules = Japanese
+	g.Init(9)
+	if g.Komi != 6.5 {
+		t.Error("Expected komi 6.5 from rules, got", g.Komi)
+	}
+	g.Komi = 0.5
+	g.Play(4, 4)
+	g.Pass()
+	g.Pass()
+	//Black surrounds the whole board
+	r := g.Result()
+	if r.String() != "B+79.5" {
+		t.Error("Expected B+79.5, got", r.String())
+	}
+}
+
+func TestGameDeadStones(t *testing.T) {
+	var g Game
+	g.Init(5)
+	//Black wall on column 1, white wall on column 3,
+	//with a dead two stone white chain at 0,1 and 0,2
+	moves := []Point{{1, 0}, {3, 0}, {1, 1}, {3, 1}, {1, 2}, {3, 2}, {1, 3}, {3, 3}, {1, 4}, {3, 4}, {4, 4}, {0, 1}, {2, 2}, {0, 2}}
+	for _, m := range moves {
+		if err := g.Play(m.X, m.Y); err != nil {
+			t.Fatal("Error playing", m, ":", err)
+		}
+	}
+	if err := g.ToggleDead(0, 1); err != ErrNotOver {
+		t.Error("Expected ErrNotOver, got", err)
+	}
+	g.Pass()
+	g.Pass()
+	if err := g.ToggleDead(0, 1); err != nil {
+		t.Error("Error marking dead:", err)
+	}
+	dead := g.Dead()
+	if len(dead) != 2 || dead[0] != (Point{0, 1}) || dead[1] != (Point{0, 2}) {
+		t.Error("Expected whole chain marked dead, got", dead)
+	}
+	g.Agree(true)
+	//Black's 4,4 stone is dead too, toggling withdraws agreement
+	g.ToggleDead(4, 4)
+	g.Agree(false)
+	if g.Agreed() {
+		t.Error("Expected agreement withdrawn after toggling")
+	}
+	g.Agree(true)
+	if !g.Agreed() {
+		t.Error("Expected both players to agree")
+	}
+	//Area: black 5 stones + 5 territory + 2,2, white 5 stones + 5 territory
+	black, white := g.Score()
+	if black != 11 || white != 10 {
+		t.Error("Expected black: 11, white: 10, got black:", black, ", white:", white)
+		t.Log(g.Board.PrettyString())
+	}
+	//Toggling again brings the chain back to life
+	g.ToggleDead(0, 2)
+	if len(g.Dead()) != 1 {
+		t.Error("Expected only 4,4 dead, got", g.Dead())
+	}
+	//Board keeps the stones, they're only treated as captured
+	if !g.Board.Grid()[1][0].White || !g.Board.Grid()[4][4].Black {
+		t.Error("Expected dead stones to remain on the Board")
+	}
+}
@@ -0,0 +1,81 @@
+package baduk
+
+import "errors"
+
+//ErrEmpty is returned when a stone is
+//expected at an empty Piece
+var ErrEmpty = errors.New("Piece is empty")
+
+//A Group is a chain of connected stones of one
+//color, with the Points of its stones and liberties
+type Group struct {
+	Black     bool
+	Stones    []Point
+	Liberties []Point
+}
+
+//Returns the number of liberties of the Group
+func (g Group) LibertyCount() int {
+	return len(g.Liberties)
+}
+
+//Returns the Group of the stone at x, y
+func (b *Board) GroupAt(x, y int) (g Group, err error) {
+	if err = b.checkRange(x, y); err != nil {
+		return
+	}
+	if b.cells[y*b.Size+x] == empty {
+		err = ErrEmpty
+		return
+	}
+	g = b.newGroup(b.wholeChain(y*b.Size + x))
+	return
+}
+
+//Returns every Group of the given color,
+//ordered by their first stone
+func (b *Board) Groups(isBlack bool) (groups []Group) {
+	checked := make([]bool, len(b.cells))
+	for i, c := range b.cells {
+		if c != stone(isBlack) || checked[i] {
+			continue
+		}
+		chain := b.wholeChain(i)
+		for _, k := range chain {
+			checked[k] = true
+		}
+		groups = append(groups, b.newGroup(chain))
+	}
+	return
+}
+
+//Makes a Group from a chain
+func (b *Board) newGroup(chain []int) (g Group) {
+	g.Black = b.cells[chain[0]] == blackStone
+	g.Stones = b.chainPoints(chain)
+	g.Liberties = b.chainPoints(b.liberties(chain, nil))
+	return
+}
+
+//Returns every Group of the given color in atari,
+//that is, with a single liberty left
+func (b *Board) Ataris(isBlack bool) (groups []Group) {
+	for _, g := range b.Groups(isBlack) {
+		if g.LibertyCount() == 1 {
+			groups = append(groups, g)
+		}
+	}
+	return
+}
+
+//Reports whether the given color playing at x, y would
+//leave its own chain with a single liberty, after any
+//captures. The move is worked out as IsLegal does, without
+//changing the Board. If the move is illegal, err is the
+//reason, as in IsLegal.
+func (b *Board) SelfAtari(isBlack bool, x, y int) (atari bool, err error) {
+	t, err := b.try(isBlack, x, y)
+	//Suicide leaves no chain, and isn't atari
+	atari = err == nil && t.libs == 1
+	return
+}
@@ -0,0 +1,102 @@
+package baduk
+
+import "testing"
+
+func TestGroupAt(t *testing.T) {
+	var b Board
+	b.Init(5)
+	b.SetB(0, 0)
+	b.SetB(1, 0)
+	b.SetB(1, 1)
+	b.SetW(2, 0)
+	g, err := b.GroupAt(1, 1)
+	if err != nil {
+		t.Fatal("Error getting group:", err)
+	}
+	if !g.Black || len(g.Stones) != 3 || g.Stones[0] != (Point{0, 0}) {
+		t.Error("Expected black group of 3 stones, got", g)
+	}
+	//Liberties at 0,1, 2,1 and 1,2
+	expect := []Point{{0, 1}, {2, 1}, {1, 2}}
+	if g.LibertyCount() != 3 {
+		t.Fatal("Expected 3 liberties, got", g.Liberties)
+	}
+	for i := range expect {
+		if g.Liberties[i] != expect[i] {
+			t.Error("Expected liberties", expect, "got", g.Liberties)
+			break
+		}
+	}
+	g, _ = b.GroupAt(2, 0)
+	if g.Black || len(g.Stones) != 1 || g.LibertyCount() != 2 {
+		t.Error("Expected white stone with 2 liberties, got", g)
+	}
+	if _, err = b.GroupAt(4, 4); err != ErrEmpty {
+		t.Error("Expected ErrEmpty, got", err)
+	}
+	if _, err = b.GroupAt(5, 4); err != ErrOutOfRange {
+		t.Error("Expected ErrOutOfRange, got", err)
+	}
+}
+
+func TestGroups(t *testing.T) {
+	var b Board
+	b.Init(5)
+	b.SetB(0, 0)
+	b.SetB(1, 0)
+	b.SetB(4, 4)
+	b.SetW(2, 2)
+	groups := b.Groups(true)
+	if len(groups) != 2 || len(groups[0].Stones) != 2 || groups[1].Stones[0] != (Point{4, 4}) {
+		t.Error("Expected 2 black groups, got", groups)
+	}
+	groups = b.Groups(false)
+	if len(groups) != 1 || groups[0].LibertyCount() != 4 {
+		t.Error("Expected 1 white group with 4 liberties, got", groups)
+	}
+}
+
+func TestAtaris(t *testing.T) {
+	var b Board
+	b.Init(5)
+	b.SetW(0, 0)
+	b.SetW(3, 3)
+	b.SetB(1, 0)
+	b.SetB(2, 2)
+	if groups := b.Ataris(false); len(groups) != 1 || groups[0].Stones[0] != (Point{0, 0}) {
+		t.Error("Expected white stone at 0,0 in atari, got", groups)
+	}
+	if groups := b.Ataris(true); len(groups) != 0 {
+		t.Error("Expected no black groups in atari, got", groups)
+	}
+}
+
+func TestSelfAtari(t *testing.T) {
+	var b Board
+	b.Init(5)
+	b.SetW(1, 0)
+	b.SetW(0, 1)
+	b.SetW(2, 1)
+	b.SetB(2, 0)
+	before, _ := b.Encode()
+	//Black at 1,1 connects to nothing and has one liberty left
+	if atari, err := b.SelfAtari(true, 1, 1); !atari || err != nil {
+		t.Error("Expected self-atari at 1,1, got", atari, err)
+	}
+	//Black at 3,0 extends the chain to two liberties
+	if atari, _ := b.SelfAtari(true, 3, 0); atari {
+		t.Error("Expected no self-atari at 3,0")
+	}
+	//White at 3,0 captures 2,0 and is safe
+	if atari, _ := b.SelfAtari(false, 3, 0); atari {
+		t.Error("Expected no self-atari after capture at 3,0")
+	}
+	if _, err := b.SelfAtari(true, 1, 0); err != ErrOccupied {
+		t.Error("Expected ErrOccupied, got", err)
+	}
+	after, _ := b.Encode()
+	if after != before || b.MoveNumber() != 4 {
+		t.Error("Expected board unchanged after checking self-atari")
+		t.Log(b.PrettyString())
+	}
+}
@@ -0,0 +1,285 @@
+package gtp
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/acityinohio/baduk"
+)
+
+//An EngineError is a failure response from an engine
+type EngineError struct {
+	Command string
+	Msg     string
+}
+
+func (e *EngineError) Error() string {
+	return "GTP " + e.Command + ": " + e.Msg
+}
+
+//A Client drives a GTP engine running as a child process,
+//keeping a mirror baduk.Board in step with the moves sent
+//and generated. Moves are checked on the mirror before
+//they're sent, so the two can't drift apart. Rules sets
+//the ko and suicide rules of the mirror, and Stderr gets
+//the engine's standard error if set, before Start. Its
+//methods may be called from several goroutines.
+type Client struct {
+	Rules  baduk.Rules
+	Stderr io.Writer
+	mu     sync.Mutex
+	cmd    *exec.Cmd
+	in     io.WriteCloser
+	out    *bufio.Reader
+	id     int
+	board  baduk.Board
+}
+
+//Starts the engine at path with args, and clears it to
+//a 19x19 Board with the komi of the Rules, whatever it
+//started with. If the engine refuses, it's stopped and
+//the error returned.
+func (c *Client) Start(path string, args ...string) (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cmd != nil {
+		return errors.New("Engine already started")
+	}
+	cmd := exec.Command(path, args...)
+	cmd.Stderr = c.Stderr
+	if c.in, err = cmd.StdinPipe(); err != nil {
+		return
+	}
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return
+	}
+	if err = cmd.Start(); err != nil {
+		return
+	}
+	c.cmd, c.out = cmd, bufio.NewReader(out)
+	komi := strconv.FormatFloat(c.Rules.Komi, 'f', -1, 64)
+	if _, err = c.send("boardsize", "19"); err == nil {
+		if _, err = c.send("clear_board"); err == nil {
+			_, err = c.send("komi", komi)
+		}
+	}
+	if err != nil {
+		c.in.Close()
+		cmd.Process.Kill()
+		cmd.Wait()
+		c.cmd = nil
+		return
+	}
+	err = c.newBoard(19)
+	return
+}
+
+//Asks the engine to quit, and waits for it to exit
+func (c *Client) Close() (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cmd == nil {
+		return
+	}
+	c.send("quit")
+	c.in.Close()
+	err = c.cmd.Wait()
+	c.cmd = nil
+	return
+}
+
+//Sends a command with its arguments, returns the
+//engine's response. A failure response returns an
+//*EngineError. Commands that change the position
+//should go through the other methods, or the mirror
+//Board will no longer match the engine's.
+func (c *Client) Send(command string, args ...string) (resp string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	resp, err = c.send(command, args...)
+	return
+}
+
+//Sends a command and reads its response, matching ids
+func (c *Client) send(command string, args ...string) (resp string, err error) {
+	if c.cmd == nil {
+		err = errors.New("Engine not started")
+		return
+	}
+	c.id++
+	id := strconv.Itoa(c.id)
+	line := id + " " + strings.Join(append([]string{command}, args...), " ") + "\n"
+	if _, err = io.WriteString(c.in, line); err != nil {
+		return
+	}
+	//The response starts with = or ?, and ends at a blank line
+	var lines []string
+	for {
+		var l string
+		if l, err = c.out.ReadString('\n'); err != nil {
+			return
+		}
+		l = strings.TrimRight(l, "\r\n")
+		if len(lines) == 0 && l == "" {
+			continue
+		}
+		if l == "" {
+			break
+		}
+		lines = append(lines, l)
+	}
+	head := lines[0]
+	ok := head[0] == '='
+	if !ok && head[0] != '?' {
+		err = errors.New("Engine response " + strconv.Quote(head) + " is not valid")
+		return
+	}
+	//Some engines leave out the id
+	rest := head[1:]
+	switch {
+	case rest == id || strings.HasPrefix(rest, id+" "):
+		lines[0] = rest[len(id):]
+	case rest != "" && rest[0] >= '0' && rest[0] <= '9':
+		err = errors.New("Engine response " + strconv.Quote(head) + " doesn't match id " + id)
+		return
+	default:
+		lines[0] = rest
+	}
+	resp = strings.TrimSpace(strings.Join(lines, "\n"))
+	if !ok {
+		err = &EngineError{command, resp}
+	}
+	return
+}
+
+//Returns the engine's name
+func (c *Client) Name() (name string, err error) {
+	return c.Send("name")
+}
+
+//Starts a new Board of size on the engine and the mirror
+func (c *Client) BoardSize(size int) (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err = c.send("boardsize", strconv.Itoa(size)); err != nil {
+		return
+	}
+	err = c.newBoard(size)
+	return
+}
+
+//Clears the Board on the engine and the mirror
+func (c *Client) ClearBoard() (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err = c.send("clear_board"); err != nil {
+		return
+	}
+	err = c.newBoard(c.board.Size)
+	return
+}
+
+//Starts a new mirror Board of size with the Client's Rules
+func (c *Client) newBoard(size int) (err error) {
+	var b baduk.Board
+	if err = b.Init(size); err != nil {
+		return
+	}
+	b.KoRule = c.Rules.Ko
+	b.AllowSuicide = c.Rules.Suicide
+	c.board = b
+	return
+}
+
+//Sets the komi on the engine
+func (c *Client) Komi(komi float64) (err error) {
+	_, err = c.Send("komi", strconv.FormatFloat(komi, 'f', -1, 64))
+	return
+}
+
+//Plays a stone of the given color. The move is played
+//on the mirror first, so an illegal move returns the
+//mirror's error, such as baduk.ErrKo, without being sent.
+func (c *Client) Play(isBlack bool, x, y int) (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err = c.board.Play(isBlack, x, y); err != nil {
+		return
+	}
+	vertex := FormatVertex(x, y, c.board.Size, false)
+	if _, err = c.send("play", FormatColor(isBlack), vertex); err != nil {
+		c.board.Undo()
+	}
+	return
+}
+
+//Passes for the given color
+func (c *Client) Pass(isBlack bool) (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err = c.send("play", FormatColor(isBlack), "pass"); err != nil {
+		return
+	}
+	c.board.Pass(isBlack)
+	return
+}
+
+//Asks the engine to move for the given color, returns
+//its move, also played on the mirror. If the engine
+//resigns, resign is true. A move the mirror finds
+//illegal is taken back on the engine, and returned
+//as an error.
+func (c *Client) GenMove(isBlack bool) (x, y int, pass, resign bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	resp, err := c.send("genmove", FormatColor(isBlack))
+	if err != nil {
+		return
+	}
+	if strings.EqualFold(resp, "resign") {
+		resign = true
+		return
+	}
+	if x, y, pass, err = ParseVertex(resp, c.board.Size); err != nil {
+		c.send("undo")
+		return
+	}
+	if pass {
+		c.board.Pass(isBlack)
+		return
+	}
+	if _, err = c.board.Play(isBlack, x, y); err != nil {
+		c.send("undo")
+		err = errors.New("Engine played illegal move " + resp + ": " + err.Error())
+	}
+	return
+}
+
+//Takes back the last move on the engine and the mirror
+func (c *Client) Undo() (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err = c.send("undo"); err != nil {
+		return
+	}
+	err = c.board.Undo()
+	return
+}
+
+//Returns the engine's score, such as "W+6.5"
+func (c *Client) FinalScore() (score string, err error) {
+	return c.Send("final_score")
+}
+
+//Returns a copy of the mirror Board
+func (c *Client) Board() *baduk.Board {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.board.Clone()
+}
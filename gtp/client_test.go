@@ -0,0 +1,165 @@
+package gtp
+
+import (
+	"bufio"
+	"errors"
+	"math/rand"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/acityinohio/baduk"
+)
+
+//Not a real test: run as a child process by startHelper,
+//it's the engine the Client talks to. "baduk" serves an
+//Engine, "preloaded" one with a stone on 9x9, "liar"
+//answers A1 to every command, "refuser" refuses them,
+//"skewed" answers with the wrong ids.
+func TestHelperEngine(t *testing.T) {
+	switch os.Getenv("GTP_HELPER_ENGINE") {
+	case "baduk":
+		e := Engine{Rand: rand.New(rand.NewSource(1))}
+		e.Serve(os.Stdin, os.Stdout)
+	case "preloaded":
+		var e Engine
+		e.Exec("boardsize 9")
+		e.Exec("play b E5")
+		e.Serve(os.Stdin, os.Stdout)
+	case "liar":
+		s := bufio.NewScanner(os.Stdin)
+		for s.Scan() {
+			id, name, _, _ := parseCommand(s.Text())
+			os.Stdout.WriteString("=" + id + " A1\n\n")
+			if name == "quit" {
+				break
+			}
+		}
+	case "skewed":
+		s := bufio.NewScanner(os.Stdin)
+		for s.Scan() {
+			id, _, _, _ := parseCommand(s.Text())
+			os.Stdout.WriteString("=" + id + "2\n\n")
+		}
+	case "refuser":
+		s := bufio.NewScanner(os.Stdin)
+		for s.Scan() {
+			id, _, _, _ := parseCommand(s.Text())
+			os.Stdout.WriteString("?" + id + " unacceptable size\n\n")
+		}
+	default:
+		return
+	}
+	os.Exit(0)
+}
+
+//Starts the test binary itself as the helper engine
+func startHelper(t *testing.T, engine string) (c *Client) {
+	c = new(Client)
+	if err := c.startHelper(engine); err != nil {
+		t.Fatal("Error starting helper engine:", err)
+	}
+	return
+}
+
+//Starts the helper engine on the Client
+func (c *Client) startHelper(engine string) (err error) {
+	os.Setenv("GTP_HELPER_ENGINE", engine)
+	defer os.Unsetenv("GTP_HELPER_ENGINE")
+	c.Stderr = os.Stderr
+	err = c.Start(os.Args[0], "-test.run=TestHelperEngine")
+	return
+}
+
+func TestClient(t *testing.T) {
+	c := startHelper(t, "baduk")
+	defer c.Close()
+	if name, err := c.Name(); err != nil || name != "baduk" {
+		t.Error("Expected name baduk, got", name, err)
+	}
+	if err := c.BoardSize(5); err != nil {
+		t.Fatal("Error setting size:", err)
+	}
+	if err := c.Play(true, 2, 2); err != nil {
+		t.Error("Error playing:", err)
+	}
+	//Illegal moves are caught by the mirror
+	if err := c.Play(false, 2, 2); err != baduk.ErrOccupied {
+		t.Error("Expected ErrOccupied, got", err)
+	}
+	x, y, pass, resign, err := c.GenMove(false)
+	if err != nil || pass || resign {
+		t.Fatal("Expected a move, got", pass, resign, err)
+	}
+	b := c.Board()
+	if p, _ := b.At(x, y); !p.White || b.MoveNumber() != 2 {
+		t.Error("Expected the mirror to have the white move at", x, y)
+	}
+	//The engine and the mirror agree
+	c.Komi(0.5)
+	score, err := c.FinalScore()
+	if err != nil || score != b.ScoreKomi(0.5).String() {
+		t.Error("Expected score", b.ScoreKomi(0.5), "got", score, err)
+	}
+	if err = c.Undo(); err != nil || c.Board().MoveNumber() != 1 {
+		t.Error("Expected undo on both, got", err)
+	}
+	var ee *EngineError
+	if _, err = c.Send("frobnicate"); !errors.As(err, &ee) || ee.Msg != "unknown command" {
+		t.Error("Expected unknown command, got", err)
+	}
+	if err = c.BoardSize(30); err == nil || c.Board().Size != 5 {
+		t.Error("Expected unacceptable size to keep the Board, got", err)
+	}
+	if err = c.Close(); err != nil {
+		t.Error("Error closing:", err)
+	}
+	if _, err = c.Send("name"); err == nil {
+		t.Error("Expected an error after Close")
+	}
+}
+
+func TestClientIllegalGenMove(t *testing.T) {
+	c := startHelper(t, "liar")
+	defer c.Close()
+	c.BoardSize(5)
+	if err := c.Play(true, 0, 4); err != nil {
+		t.Fatal("Error playing A1:", err)
+	}
+	//The liar answers A1 again, which the mirror refuses
+	if _, _, _, _, err := c.GenMove(false); err == nil {
+		t.Error("Expected an error for an illegal engine move")
+	}
+	if b := c.Board(); b.MoveNumber() != 1 {
+		t.Error("Expected the mirror unchanged, got", b.MoveNumber(), "moves")
+	}
+}
+
+func TestClientStart(t *testing.T) {
+	//The engine is cleared to 19x19, with the komi of the Rules
+	c := Client{Rules: baduk.Chinese}
+	if err := c.startHelper("preloaded"); err != nil {
+		t.Fatal("Error starting helper engine:", err)
+	}
+	defer c.Close()
+	if score, err := c.FinalScore(); err != nil || score != "W+7.5" {
+		t.Error("Expected an empty board and W+7.5, got", score, err)
+	}
+	if err := c.Play(true, 18, 18); err != nil {
+		t.Error("Expected T1 on 19x19 to be legal, got", err)
+	}
+	//An engine that refuses is stopped
+	var r Client
+	var ee *EngineError
+	if err := r.startHelper("refuser"); !errors.As(err, &ee) || ee.Command != "boardsize" {
+		t.Error("Expected boardsize to be refused, got", err)
+	}
+	if _, err := r.Send("name"); err == nil {
+		t.Error("Expected the refusing engine to be stopped")
+	}
+	//A response to id 12 isn't one to id 1
+	var s Client
+	if err := s.startHelper("skewed"); err == nil || !strings.Contains(err.Error(), "doesn't match id 1") {
+		t.Error("Expected mismatched ids, got", err)
+	}
+}
@@ -0,0 +1,290 @@
+package gtp
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"math/rand"
+	"strconv"
+	"strings"
+
+	"github.com/acityinohio/baduk"
+)
+
+//Errors answered to the controller, worded as GTP expects
+var (
+	errUnknown    = errors.New("unknown command")
+	errSyntax     = errors.New("syntax error")
+	errSize       = errors.New("unacceptable size")
+	errIllegal    = errors.New("illegal move")
+	errCannotUndo = errors.New("cannot undo")
+)
+
+//The commands an Engine knows, in the order listed
+var commands = []string{
+	"protocol_version",
+	"name",
+	"version",
+	"known_command",
+	"list_commands",
+	"quit",
+	"boardsize",
+	"clear_board",
+	"komi",
+	"play",
+	"genmove",
+	"undo",
+	"showboard",
+	"final_score",
+}
+
+//An Engine answers GTP commands with a baduk.Board.
+//Rules sets the ko and suicide rules of each Board, the
+//scoring of final_score and the komi until the komi
+//command, Rand picks the moves of genmove. The zero value
+//plays on 19x19 with SimpleKo, area scoring and no komi,
+//with moves from the default math/rand source.
+type Engine struct {
+	Name    string
+	Version string
+	Rules   baduk.Rules
+	Rand    *rand.Rand
+	board   baduk.Board
+	komi    float64
+}
+
+//Reads commands from r until quit or the end of
+//the input, writing each response to w
+func (e *Engine) Serve(r io.Reader, w io.Writer) (err error) {
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		resp, quit := e.Exec(s.Text())
+		if resp == "" {
+			continue
+		}
+		if _, err = io.WriteString(w, resp); err != nil {
+			return
+		}
+		if quit {
+			return
+		}
+	}
+	err = s.Err()
+	return
+}
+
+//Runs a single command line, returns the full response,
+//ending in a blank line, or "" if the line was empty.
+//quit is true after the quit command.
+func (e *Engine) Exec(line string) (resp string, quit bool) {
+	id, name, args, ok := parseCommand(line)
+	if !ok {
+		return
+	}
+	if e.board.Size == 0 {
+		e.komi = e.Rules.Komi
+		e.newBoard(19)
+	}
+	result, err := e.run(name, args)
+	if err != nil {
+		resp = "?" + id + " " + err.Error() + "\n\n"
+		return
+	}
+	resp = "=" + id
+	if result != "" {
+		resp += " " + result
+	}
+	resp += "\n\n"
+	quit = name == "quit"
+	return
+}
+
+//Starts a new Board of size with the Engine's
+//Rules, keeping the komi
+func (e *Engine) newBoard(size int) (err error) {
+	var b baduk.Board
+	if err = b.Init(size); err != nil {
+		return
+	}
+	b.KoRule = e.Rules.Ko
+	b.AllowSuicide = e.Rules.Suicide
+	e.board = b
+	return
+}
+
+//Runs a command, returns its result
+func (e *Engine) run(name string, args []string) (result string, err error) {
+	switch name {
+	case "protocol_version":
+		result = "2"
+	case "name":
+		result = e.Name
+		if result == "" {
+			result = "baduk"
+		}
+	case "version":
+		result = e.Version
+	case "known_command":
+		result = "false"
+		for _, c := range commands {
+			if len(args) > 0 && args[0] == c {
+				result = "true"
+			}
+		}
+	case "list_commands":
+		result = strings.Join(commands, "\n")
+	case "quit":
+	case "boardsize":
+		size, e2 := strconv.Atoi(arg(args, 0))
+		if e2 != nil {
+			err = errSyntax
+		} else if e.newBoard(size) != nil {
+			err = errSize
+		}
+	case "clear_board":
+		e.newBoard(e.board.Size)
+	case "komi":
+		komi, e2 := strconv.ParseFloat(arg(args, 0), 64)
+		if e2 != nil {
+			err = errSyntax
+			return
+		}
+		e.komi = komi
+	case "play":
+		err = e.play(args)
+	case "genmove":
+		result, err = e.genmove(args)
+	case "undo":
+		if e.board.Undo() != nil {
+			err = errCannotUndo
+		}
+	case "showboard":
+		result = e.showboard()
+	case "final_score":
+		result = e.finalScore().String()
+	default:
+		err = errUnknown
+	}
+	return
+}
+
+//Scores the Board by the Engine's Rules, with its komi.
+//No stones are taken as dead.
+func (e *Engine) finalScore() baduk.Result {
+	if e.Rules.Scoring != baduk.TerritoryScoring {
+		return e.board.ScoreKomi(e.komi)
+	}
+	black, white := e.board.ScoreTerritory(nil)
+	return baduk.NewResult(black, white, e.komi)
+}
+
+//Returns argument i, or "" if there's none
+func arg(args []string, i int) string {
+	if i < len(args) {
+		return args[i]
+	}
+	return ""
+}
+
+//Plays the move in args, a color and a vertex
+func (e *Engine) play(args []string) (err error) {
+	if len(args) != 2 {
+		return errSyntax
+	}
+	black, err := ParseColor(args[0])
+	if err != nil {
+		return errSyntax
+	}
+	x, y, pass, err := ParseVertex(args[1], e.board.Size)
+	if err != nil {
+		return errSyntax
+	}
+	if pass {
+		e.board.Pass(black)
+		return
+	}
+	if _, err = e.board.Play(black, x, y); err != nil {
+		return errIllegal
+	}
+	return
+}
+
+//Picks a random legal move for the color in args that
+//doesn't fill one of its own eyes, plays it and returns
+//its vertex. Passes if there's no such move.
+func (e *Engine) genmove(args []string) (vertex string, err error) {
+	black, err := ParseColor(arg(args, 0))
+	if err != nil {
+		return "", errSyntax
+	}
+	var moves []baduk.Point
+	for _, p := range e.board.LegalMoves(black) {
+		if !e.isEye(p.X, p.Y, black) {
+			moves = append(moves, p)
+		}
+	}
+	if len(moves) == 0 {
+		e.board.Pass(black)
+		vertex = FormatVertex(0, 0, e.board.Size, true)
+		return
+	}
+	var i int
+	if e.Rand != nil {
+		i = e.Rand.Intn(len(moves))
+	} else {
+		i = rand.Intn(len(moves))
+	}
+	p := moves[i]
+	if _, err = e.board.Play(black, p.X, p.Y); err != nil {
+		return
+	}
+	vertex = FormatVertex(p.X, p.Y, e.board.Size, false)
+	return
+}
+
+//Returns true if every neighbor of x, y
+//is a stone of the given color
+func (e *Engine) isEye(x, y int, black bool) bool {
+	for _, d := range [4][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}} {
+		p, err := e.board.At(x+d[0], y+d[1])
+		if err != nil {
+			continue
+		}
+		if p.Empty || p.Black != black {
+			return false
+		}
+	}
+	return true
+}
+
+//Draws the Board with coordinates, X for black,
+//O for white, with no blank lines
+func (e *Engine) showboard() (str string) {
+	size := e.board.Size
+	header := "  "
+	for x := 0; x < size; x++ {
+		header += " " + letters[x:x+1]
+	}
+	str = "\n" + header + "\n"
+	for y := 0; y < size; y++ {
+		row := strconv.Itoa(size - y)
+		line := row
+		if len(row) < 2 {
+			line = " " + row
+		}
+		for x := 0; x < size; x++ {
+			p, _ := e.board.At(x, y)
+			switch {
+			case p.Black:
+				line += " X"
+			case p.White:
+				line += " O"
+			default:
+				line += " ."
+			}
+		}
+		str += line + " " + row + "\n"
+	}
+	str += header
+	return
+}
@@ -0,0 +1,85 @@
+package gtp
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/acityinohio/baduk"
+)
+
+func TestEngine(t *testing.T) {
+	e := Engine{Rules: baduk.Japanese}
+	in := strings.Join([]string{
+		"1 protocol_version",
+		"",
+		"2 boardsize 25",
+		"3 boardsize 5",
+		"4 play black C3",
+		"5 play white C3",
+		"6 play white B3",
+		"7 known_command genmove",
+		"8 final_score",
+		"9 komi 0.5",
+		"10 undo",
+		"11 final_score",
+		"12 play b pass",
+		"13 frobnicate",
+		"14 quit",
+		"15 name",
+	}, "\n")
+	var out bytes.Buffer
+	if err := e.Serve(strings.NewReader(in), &out); err != nil {
+		t.Fatal("Error serving:", err)
+	}
+	want := "=1 2\n\n" +
+		"?2 unacceptable size\n\n" +
+		"=3\n\n" +
+		"=4\n\n" +
+		"?5 illegal move\n\n" +
+		"=6\n\n" +
+		"=7 true\n\n" +
+		//Territory scoring, with Japanese komi 6.5
+		"=8 W+6.5\n\n" +
+		"=9\n\n" +
+		"=10\n\n" +
+		//Black's stone isn't territory
+		"=11 B+23.5\n\n" +
+		"=12\n\n" +
+		"?13 unknown command\n\n" +
+		"=14\n\n"
+	if out.String() != want {
+		t.Error("Expected", want, "got", out.String())
+	}
+}
+
+func TestGenmove(t *testing.T) {
+	e := Engine{Rand: rand.New(rand.NewSource(1))}
+	e.Exec("boardsize 4")
+	//Black fills everything but its two eyes, then passes
+	for _, v := range []string{"A4", "B4", "C4", "D4", "A3", "C3", "D3", "A2", "B2", "C2", "D2", "B1", "C1", "D1"} {
+		if resp, _ := e.Exec("play b " + v); resp != "=\n\n" {
+			t.Fatal("Error playing", v, resp)
+		}
+	}
+	if resp, _ := e.Exec("genmove b"); resp != "= pass\n\n" {
+		t.Error("Expected pass, got", resp)
+	}
+	//White has nowhere to go but suicide
+	if resp, _ := e.Exec("genmove w"); resp != "= pass\n\n" {
+		t.Error("Expected pass, got", resp)
+	}
+	e.Exec("clear_board")
+	resp, _ := e.Exec("genmove w")
+	x, y, pass, err := ParseVertex(strings.TrimSpace(strings.TrimPrefix(resp, "=")), 4)
+	if err != nil || pass {
+		t.Fatal("Expected a move, got", resp)
+	}
+	if p, _ := e.board.At(x, y); !p.White {
+		t.Error("Expected the move to be played, got", e.showboard())
+	}
+	if show, _ := e.Exec("showboard"); strings.Contains(show[:len(show)-2], "\n\n") {
+		t.Error("Expected no blank lines in showboard, got", show)
+	}
+}
@@ -0,0 +1,106 @@
+//Package gtp speaks the Go Text Protocol (GTP version 2),
+//the way Go programs like GoGui and Sabaki talk to engines.
+//An Engine answers GTP commands with a baduk.Board, and a
+//Client drives another engine as a child process.
+package gtp
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+//Column letters of vertices, I is skipped
+const letters = "ABCDEFGHJKLMNOPQRST"
+
+//Reads a GTP vertex such as "D4" or "pass" on a Board
+//of size. Rows count up from the bottom, so row 1 is
+//y = size-1 on a baduk.Board.
+func ParseVertex(s string, size int) (x, y int, pass bool, err error) {
+	s = strings.ToUpper(s)
+	if s == "PASS" {
+		pass = true
+		return
+	}
+	if len(s) < 2 {
+		err = errors.New("Vertex " + strconv.Quote(s) + " is not valid")
+		return
+	}
+	x = strings.IndexByte(letters, s[0])
+	row, e := strconv.Atoi(s[1:])
+	if x < 0 || e != nil {
+		err = errors.New("Vertex " + strconv.Quote(s) + " is not valid")
+		return
+	}
+	y = size - row
+	if x >= size || row < 1 || row > size {
+		err = errors.New("Vertex " + strconv.Quote(s) + " is off the board")
+	}
+	return
+}
+
+//Writes x, y on a Board of size as a GTP vertex,
+//or "pass"
+func FormatVertex(x, y, size int, pass bool) string {
+	if pass {
+		return "pass"
+	}
+	return letters[x:x+1] + strconv.Itoa(size-y)
+}
+
+//Reads a GTP color, "b", "black", "w" or "white"
+func ParseColor(s string) (isBlack bool, err error) {
+	switch strings.ToLower(s) {
+	case "b", "black":
+		isBlack = true
+	case "w", "white":
+	default:
+		err = errors.New("Color " + strconv.Quote(s) + " is not valid")
+	}
+	return
+}
+
+//Writes a GTP color
+func FormatColor(isBlack bool) string {
+	if isBlack {
+		return "B"
+	}
+	return "W"
+}
+
+//Cleans a line as GTP asks: control characters
+//other than tabs are dropped, tabs become spaces,
+//and comments from # on are removed
+func preprocess(line string) string {
+	if i := strings.IndexByte(line, '#'); i >= 0 {
+		line = line[:i]
+	}
+	clean := make([]byte, 0, len(line))
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case c == '\t':
+			clean = append(clean, ' ')
+		case c < 32 || c == 127:
+		default:
+			clean = append(clean, c)
+		}
+	}
+	return strings.TrimSpace(string(clean))
+}
+
+//Splits a command line into its optional numeric id,
+//the command name and its arguments. ok is false if
+//the line is empty once cleaned.
+func parseCommand(line string) (id, name string, args []string, ok bool) {
+	fields := strings.Fields(preprocess(line))
+	if len(fields) > 0 {
+		if _, err := strconv.Atoi(fields[0]); err == nil {
+			id, fields = fields[0], fields[1:]
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+	name, args, ok = fields[0], fields[1:], true
+	return
+}
@@ -0,0 +1,46 @@
+package gtp
+
+import "testing"
+
+func TestVertex(t *testing.T) {
+	cases := []struct {
+		s    string
+		x, y int
+		pass bool
+	}{
+		{"A1", 0, 18, false},
+		{"j10", 8, 9, false},
+		{"T19", 18, 0, false},
+		{"PASS", 0, 0, true},
+	}
+	for _, c := range cases {
+		x, y, pass, err := ParseVertex(c.s, 19)
+		if err != nil || x != c.x || y != c.y || pass != c.pass {
+			t.Error("Expected", c.x, c.y, c.pass, "for", c.s, "got", x, y, pass, err)
+		}
+	}
+	for _, s := range []string{"I5", "A0", "A20", "U1", "5", "pas"} {
+		if _, _, _, err := ParseVertex(s, 19); err == nil {
+			t.Error("Expected error for", s)
+		}
+	}
+	if _, _, _, err := ParseVertex("F1", 5); err == nil {
+		t.Error("Expected F1 off a 5x5 board")
+	}
+	if v := FormatVertex(8, 9, 19, false); v != "J10" {
+		t.Error("Expected J10, got", v)
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	id, name, args, ok := parseCommand("12 play\tb D4 # comment\r")
+	if !ok || id != "12" || name != "play" || len(args) != 2 || args[1] != "D4" {
+		t.Error("Expected 12 play b D4, got", id, name, args)
+	}
+	if _, _, _, ok = parseCommand("  # just a comment"); ok {
+		t.Error("Expected a comment line to be empty")
+	}
+	if black, err := ParseColor("White"); err != nil || black {
+		t.Error("Expected white, got", black, err)
+	}
+}
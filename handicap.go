@@ -0,0 +1,133 @@
+package baduk
+
+import (
+	"errors"
+	"strconv"
+)
+
+//Returns the conventional star points for a fixed
+//handicap of stones on a Board of size, in the order
+//they're placed. Odd sizes from 7 up allow 2 to 9 stones,
+//other sizes allow 2 to 4 stones in the corners.
+func HandicapPoints(size, stones int) (pts []Point, err error) {
+	if size < 4 || size > 19 {
+		err = errors.New("Size of board must be between 4 and 19")
+		return
+	}
+	max := 4
+	if size >= 7 && size%2 == 1 {
+		max = 9
+	}
+	if stones < 2 || stones > max {
+		err = errors.New("Handicap must be between 2 and " + strconv.Itoa(max) + " stones for this size")
+		return
+	}
+	//Distance of star points from the edge
+	d := 3
+	switch {
+	case size < 7:
+		d = 1
+	case size < 13:
+		d = 2
+	}
+	lo, hi, mid := d, size-1-d, size/2
+	//Corners first, lower left and upper right,
+	//then upper left and lower right
+	pts = []Point{{lo, hi}, {hi, lo}, {lo, lo}, {hi, hi}}
+	//Sides then center: odd stones take the center,
+	//six or more take the left and right sides,
+	//eight or more take the top and bottom sides
+	if stones >= 6 {
+		pts = append(pts, Point{lo, mid}, Point{hi, mid})
+	}
+	if stones >= 8 {
+		pts = append(pts, Point{mid, hi}, Point{mid, lo})
+	}
+	if stones >= 5 && stones%2 == 1 {
+		pts = append(pts, Point{mid, mid})
+	}
+	if stones < 4 {
+		pts = pts[:stones]
+	}
+	return
+}
+
+//Places a fixed handicap of black stones on the star
+//points of an empty Board, returns the Points placed.
+//The stones are setup, not moves: the history restarts
+//from them with White to play, so they can't be undone.
+func (b *Board) PlaceHandicap(stones int) (pts []Point, err error) {
+	if !b.isEmpty() {
+		err = errors.New("Board must be empty for handicap")
+		return
+	}
+	if pts, err = HandicapPoints(b.Size, stones); err != nil {
+		return
+	}
+	b.fill(pts, true)
+	b.ko = nil
+	b.undo, b.redo = nil, nil
+	b.resetHistory(false)
+	return
+}
+
+//Places a fixed handicap of black stones on the star
+//points, before any moves, and sets the handicap komi
+//of the Rules. White plays next.
+func (g *Game) SetHandicap(stones int) (err error) {
+	if err = g.checkHandicap(); err != nil {
+		return
+	}
+	pts, err := g.Board.PlaceHandicap(stones)
+	if err != nil {
+		return
+	}
+	g.Handicap = pts
+	g.Komi = g.Rules.HandicapKomi
+	g.whiteToPlay = true
+	return
+}
+
+//Starts free placement of a handicap, before any moves.
+//Black's next plays place the handicap stones anywhere,
+//then White plays next. Sets the handicap komi of the Rules.
+func (g *Game) FreeHandicap(stones int) (err error) {
+	if err = g.checkHandicap(); err != nil {
+		return
+	}
+	if stones < 2 || stones > 9 {
+		err = errors.New("Handicap must be between 2 and 9 stones")
+		return
+	}
+	g.freeHandicap = stones
+	g.Komi = g.Rules.HandicapKomi
+	return
+}
+
+//Returns an error unless the Game is
+//ready for handicap stones
+func (g *Game) checkHandicap() (err error) {
+	if len(g.Moves) > 0 || len(g.Handicap) > 0 || g.freeHandicap > 0 {
+		err = errors.New("Handicap must be placed before any moves")
+	}
+	return
+}
+
+//Places a free handicap stone as setup, like
+//PlaceHandicap, once all are placed White plays next
+func (g *Game) placeFree(x, y int) (err error) {
+	b := &g.Board
+	if err = b.checkRange(x, y); err != nil {
+		return
+	}
+	if b.cells[y*b.Size+x] != empty {
+		err = ErrOccupied
+		return
+	}
+	b.fill([]Point{{x, y}}, true)
+	g.Handicap = append(g.Handicap, Point{x, y})
+	g.freeHandicap--
+	g.whiteToPlay = g.freeHandicap == 0
+	b.resetHistory(!g.whiteToPlay)
+	return
+}
@@ -0,0 +1,101 @@
+package baduk
+
+import "testing"
+
+func TestHandicapPoints(t *testing.T) {
+	pts, err := HandicapPoints(19, 9)
+	if err != nil {
+		t.Fatal("Error getting handicap points:", err)
+	}
+	expect := []Point{{3, 15}, {15, 3}, {3, 3}, {15, 15}, {3, 9}, {15, 9}, {9, 15}, {9, 3}, {9, 9}}
+	for i := range expect {
+		if pts[i] != expect[i] {
+			t.Error("Expected", expect, "got", pts)
+			break
+		}
+	}
+	pts, _ = HandicapPoints(9, 5)
+	if len(pts) != 5 || pts[0] != (Point{2, 6}) || pts[4] != (Point{4, 4}) {
+		t.Error("Expected 5 stones on 9x9 ending in the center, got", pts)
+	}
+	pts, _ = HandicapPoints(13, 3)
+	if len(pts) != 3 || pts[2] != (Point{3, 3}) {
+		t.Error("Expected 3 stones on 13x13, got", pts)
+	}
+	if _, err = HandicapPoints(19, 1); err == nil {
+		t.Error("Expected error for 1 stone handicap")
+	}
+	if _, err = HandicapPoints(10, 5); err == nil {
+		t.Error("Expected error for 5 stones on even board")
+	}
+	pts, _ = HandicapPoints(6, 4)
+	if len(pts) != 4 || pts[2] != (Point{1, 1}) {
+		t.Error("Expected 4 stones on 6x6, got", pts)
+	}
+}
+
+func TestGameHandicap(t *testing.T) {
+	var g Game
+	g.Rules = Chinese
+	g.Init(9)
+	if err := g.SetHandicap(4); err != nil {
+		t.Fatal("Error setting handicap:", err)
+	}
+	if g.BlackToPlay() {
+		t.Error("Expected White to play after handicap")
+	}
+	if !g.Board.Grid()[2][2].Black || !g.Board.Grid()[6][6].Black {
+		t.Error("Expected stones on the star points")
+	}
+	if err := g.SetHandicap(2); err == nil {
+		t.Error("Expected error setting handicap twice")
+	}
+	//Handicap stones are setup, not moves
+	if g.Board.MoveNumber() != 0 || g.Board.Undo() == nil {
+		t.Error("Expected no moves to undo, got", g.Board.MoveNumber())
+	}
+	g.Pass()
+	g.Pass()
+	//Black owns the board, but white gets the handicap
+	//komi and 4 points for the handicap
+	r := g.Result()
+	if r.Komi != 4.5 || r.String() != "B+76.5" {
+		t.Error("Expected komi 4.5 and B+76.5, got", r.Komi, r.String())
+	}
+	//Japanese rules give no points for the stones
+	g.Rules = Japanese
+	g.Init(9)
+	g.SetHandicap(2)
+	if g.Komi != 0.5 {
+		t.Error("Expected komi 0.5, got", g.Komi)
+	}
+}
+
+func TestGameFreeHandicap(t *testing.T) {
+	var g Game
+	g.Init(9)
+	if err := g.FreeHandicap(2); err != nil {
+		t.Fatal("Error starting free handicap:", err)
+	}
+	if err := g.Pass(); err == nil {
+		t.Error("Expected error passing during handicap placement")
+	}
+	g.Play(0, 0)
+	if !g.BlackToPlay() {
+		t.Error("Expected Black to place the second stone")
+	}
+	g.Play(8, 8)
+	if g.BlackToPlay() {
+		t.Error("Expected White to play after handicap")
+	}
+	if len(g.Handicap) != 2 || len(g.Moves) != 0 {
+		t.Error("Expected 2 handicap stones and no moves, got", g.Handicap, g.Moves)
+	}
+	if g.Board.MoveNumber() != 0 || g.Undo() == nil {
+		t.Error("Expected the handicap stones not to be undone")
+	}
+	g.Play(4, 4)
+	if !g.Board.Grid()[4][4].White {
+		t.Error("Expected white stone at 4,4")
+	}
+}
@@ -0,0 +1,113 @@
+package baduk
+
+import "errors"
+
+//Reads out a ladder on the chain at x, y. If the chain
+//has two liberties, its opponent attacks first; if it's
+//in atari, it tries to run first. Returns true if the ladder
+//works and the chain is captured, with the sequence of moves
+//read. Moves are played with set on a Clone of the Board,
+//so ladder breakers, counter-captures and ko follow real play.
+func (b *Board) Ladder(x, y int) (works bool, seq []Move, err error) {
+	g, err := b.GroupAt(x, y)
+	if err != nil {
+		return
+	}
+	c := b.Clone()
+	switch g.LibertyCount() {
+	case 1:
+		works, seq = c.ladderRun(x, y, 0)
+	case 2:
+		works, seq = c.ladderChase(x, y, 0)
+	default:
+		err = errors.New("Group has more than two liberties")
+	}
+	return
+}
+
+//Attacker's turn: tries each liberty of the chain at x, y
+//as an atari, returns true and the sequence if one captures
+func (b *Board) ladderChase(x, y, depth int) (works bool, seq []Move) {
+	i := y*b.Size + x
+	libs := b.chainPoints(b.liberties(b.wholeChain(i), nil))
+	attacker := b.cells[i] != blackStone
+	switch {
+	case len(libs) == 1:
+		res, err := b.set(libs[0].X, libs[0].Y, attacker)
+		if err != nil {
+			return
+		}
+		b.Undo()
+		works = true
+		seq = []Move{{Black: attacker, X: libs[0].X, Y: libs[0].Y, MoveResult: res}}
+		return
+	case len(libs) > 2, depth > b.Size*b.Size:
+		return
+	}
+	for _, l := range libs {
+		res, err := b.set(l.X, l.Y, attacker)
+		if err != nil {
+			continue
+		}
+		ok, rest := b.ladderRun(x, y, depth+1)
+		b.Undo()
+		if ok {
+			works = true
+			seq = append([]Move{{Black: attacker, X: l.X, Y: l.Y, MoveResult: res}}, rest...)
+			return
+		}
+	}
+	return
+}
+
+//Defender's turn, with the chain at x, y in atari: tries
+//extending and capturing adjacent chains in atari, returns
+//true and the main line if every escape fails
+func (b *Board) ladderRun(x, y, depth int) (works bool, seq []Move) {
+	i := y*b.Size + x
+	chain := b.wholeChain(i)
+	libs := b.chainPoints(b.liberties(chain, nil))
+	defender := b.cells[i] == blackStone
+	if len(libs) != 1 {
+		return
+	}
+	//Extending comes first, then counter-captures
+	escapes := libs
+	checked := make([]bool, len(b.cells))
+	for _, c := range chain {
+		for _, n := range b.adj[c] {
+			if b.cells[n] != stone(!defender) || checked[n] {
+				continue
+			}
+			enemy := b.wholeChain(n)
+			for _, e := range enemy {
+				checked[e] = true
+			}
+			if l := b.liberties(enemy, nil); len(l) == 1 {
+				escapes = append(escapes, b.point(l[0]))
+			}
+		}
+	}
+	tried := false
+	for _, e := range escapes {
+		res, err := b.set(e.X, e.Y, defender)
+		if err != nil {
+			continue
+		}
+		ok, rest := b.ladderChase(x, y, depth+1)
+		b.Undo()
+		if !ok {
+			return false, nil
+		}
+		if !tried {
+			seq = append([]Move{{Black: defender, X: e.X, Y: e.Y, MoveResult: res}}, rest...)
+			tried = true
+		}
+	}
+	//With no legal escape, the attacker just captures
+	if !tried {
+		return b.ladderChase(x, y, depth+1)
+	}
+	works = true
+	return
+}
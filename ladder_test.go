@@ -0,0 +1,63 @@
+package baduk
+
+import "testing"
+
+//Sets up a white stone at 2,2 with two liberties,
+//ready to be laddered toward the bottom right
+func setupLadder(b *Board) {
+	b.Init(9)
+	b.SetW(2, 2)
+	b.SetB(2, 1)
+	b.SetB(1, 2)
+	b.SetB(3, 1)
+	return
+}
+
+func TestLadder(t *testing.T) {
+	var b Board
+	setupLadder(&b)
+	before, _ := b.Encode()
+	works, seq, err := b.Ladder(2, 2)
+	if err != nil {
+		t.Fatal("Error reading ladder:", err)
+	}
+	if !works {
+		t.Fatal("Expected ladder to work")
+	}
+	//Black ataris first, and captures last
+	first, last := seq[0], seq[len(seq)-1]
+	if !first.Black || first.X != 2 || first.Y != 3 {
+		t.Error("Expected black atari at 2,3 first, got", first)
+	}
+	if !last.Black || len(last.Captured) != 1 || len(last.Captured[0]) < 10 {
+		t.Error("Expected black to capture the ladder last, got", last)
+	}
+	after, _ := b.Encode()
+	if after != before || b.MoveNumber() != 4 {
+		t.Error("Expected board unchanged after reading")
+		t.Log(b.PrettyString())
+	}
+	//In atari, white runs first and still dies
+	b.SetB(2, 3)
+	works, seq, _ = b.Ladder(2, 2)
+	if !works || seq[0].Black || seq[0].X != 3 || seq[0].Y != 2 {
+		t.Error("Expected white to run at 3,2 and be captured, got", works, seq)
+	}
+}
+
+func TestLadderBreaker(t *testing.T) {
+	var b Board
+	setupLadder(&b)
+	//A white stone in the ladder's path breaks it
+	b.SetW(6, 6)
+	works, _, err := b.Ladder(2, 2)
+	if err != nil || works {
+		t.Error("Expected ladder to fail with breaker, got", works, err)
+	}
+	//An extra liberty means there's no ladder to read
+	b.Init(9)
+	b.SetW(4, 4)
+	if _, _, err = b.Ladder(4, 4); err == nil {
+		t.Error("Expected error reading a group with four liberties")
+	}
+}
@@ -0,0 +1,113 @@
+package baduk
+
+//A trial is what a move would do, worked out without
+//playing it: the opponent stones it would capture, the
+//mover's chain including the new stone, that chain's
+//liberties afterward, and the hash of the new position
+type trial struct {
+	captured []int
+	chain    []int
+	libs     int
+	hash     uint64
+}
+
+//Works out what the given color playing at x, y would do,
+//as set would play it, but without changing the Board.
+//If the move is illegal, err is the reason, as in IsLegal.
+func (b *Board) try(isBlack bool, x, y int) (t trial, err error) {
+	if err = b.checkRange(x, y); err != nil {
+		return
+	}
+	i := y*b.Size + x
+	if b.cells[i] != empty {
+		err = ErrOccupied
+		return
+	}
+	if b.ko != nil && *b.ko == (Point{x, y}) && b.koBlack == isBlack {
+		err = ErrKo
+		return
+	}
+	own, opp := stone(isBlack), stone(!isBlack)
+	//seen marks stones already crawled, taken the
+	//indexes emptied by captures, libs the liberties
+	seen, taken, libs := getScratch(), getScratch(), getScratch()
+	defer putScratch(seen)
+	defer putScratch(taken)
+	defer putScratch(libs)
+	seen.newMark(len(b.cells))
+	taken.newMark(len(b.cells))
+	libs.newMark(len(b.cells))
+	t.chain = []int{i}
+	seen.setMark(i)
+	for _, n := range b.adj[i] {
+		if b.cells[n] == empty || !seen.setMark(n) {
+			continue
+		}
+		chain := b.wholeChain(n)
+		for _, k := range chain {
+			seen.setMark(k)
+		}
+		switch {
+		case b.cells[n] == own:
+			t.chain = append(t.chain, chain...)
+		//An opponent chain whose last liberty is i is captured
+		case b.cells[n] == opp && len(b.liberties(chain, nil)) == 1:
+			t.captured = append(t.captured, chain...)
+			for _, k := range chain {
+				taken.setMark(k)
+			}
+		}
+	}
+	libs.setMark(i)
+	for _, k := range t.chain {
+		for _, n := range b.adj[k] {
+			if (b.cells[n] == empty || taken.marked(n)) && libs.setMark(n) {
+				t.libs++
+			}
+		}
+	}
+	//Single stone suicide is never allowed
+	if t.libs == 0 && (!b.AllowSuicide || len(t.chain) == 1) {
+		err = ErrSuicide
+		return
+	}
+	t.hash = b.hash ^ zobristKey(x, y, isBlack)
+	for _, k := range t.captured {
+		p := b.point(k)
+		t.hash ^= zobristKey(p.X, p.Y, !isBlack)
+	}
+	if t.libs == 0 {
+		for _, k := range t.chain {
+			p := b.point(k)
+			t.hash ^= zobristKey(p.X, p.Y, isBlack)
+		}
+	}
+	if b.repeats(t.hash, !isBlack) {
+		err = ErrSuperko
+	}
+	return
+}
+
+//Reports whether the given color may play at x, y.
+//If not, reason is ErrOutOfRange, ErrOccupied, ErrKo,
+//ErrSuicide or ErrSuperko. The move is worked out
+//without being played, so the Board isn't changed.
+func (b *Board) IsLegal(isBlack bool, x, y int) (legal bool, reason error) {
+	if _, reason = b.try(isBlack, x, y); reason != nil {
+		return
+	}
+	legal = true
+	return
+}
+
+//Returns the Points where the given color may play
+func (b *Board) LegalMoves(isBlack bool) (moves []Point) {
+	for y := 0; y < b.Size; y++ {
+		for x := 0; x < b.Size; x++ {
+			if legal, _ := b.IsLegal(isBlack, x, y); legal {
+				moves = append(moves, Point{x, y})
+			}
+		}
+	}
+	return
+}
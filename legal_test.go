@@ -0,0 +1,116 @@
+package baduk
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestIsLegal(t *testing.T) {
+	var b Board
+	b.Init(5)
+	setupKo(&b)
+	b.SetW(3, 4)
+	b.SetW(4, 3)
+	b.SetB(2, 1)
+	before, _ := b.Encode()
+	hash, moves := b.hash, b.MoveNumber()
+	tests := []struct {
+		black  bool
+		x, y   int
+		reason error
+	}{
+		{true, 5, 0, ErrOutOfRange},
+		{false, 0, -1, ErrOutOfRange},
+		{false, 2, 1, ErrOccupied},
+		{false, 1, 1, ErrKo},
+		{true, 4, 4, ErrSuicide},
+		{true, 1, 1, nil},
+		{false, 0, 0, ErrSuicide},
+		{false, 3, 3, nil},
+	}
+	for _, test := range tests {
+		legal, reason := b.IsLegal(test.black, test.x, test.y)
+		if reason != test.reason || legal != (test.reason == nil) {
+			t.Error("For", test.x, test.y, "expected", test.reason, "got", legal, reason)
+		}
+	}
+	after, _ := b.Encode()
+	if after != before || b.hash != hash || b.MoveNumber() != moves || b.ko == nil {
+		t.Error("Expected board unchanged after checking legality")
+		t.Log(b.PrettyString())
+	}
+	//Superko is reported too
+	b.KoRule = PositionalSuperko
+	b.pass(false)
+	b.pass(true)
+	if _, reason := b.IsLegal(false, 1, 1); reason != ErrSuperko {
+		t.Error("Expected ErrSuperko, got", reason)
+	}
+	//The redo stack survives
+	b.Undo()
+	b.IsLegal(true, 3, 3)
+	if err := b.Redo(); err != nil {
+		t.Error("Expected redo to survive legality check, got", err)
+	}
+}
+
+func TestLegalMoves(t *testing.T) {
+	var b Board
+	b.Init(4)
+	if moves := b.LegalMoves(true); len(moves) != 16 {
+		t.Error("Expected 16 legal moves on empty board, got", len(moves))
+	}
+	b.SetW(0, 1)
+	b.SetW(1, 0)
+	//Black can't play the suicide at 0,0 or the two occupied points
+	moves := b.LegalMoves(true)
+	if len(moves) != 13 || moves[0] != (Point{2, 0}) {
+		t.Error("Expected 13 legal moves starting at 2,0, got", moves)
+	}
+	if moves = b.LegalMoves(false); len(moves) != 14 {
+		t.Error("Expected 14 legal moves for white, got", len(moves))
+	}
+}
+
+func TestIsLegalMatchesSet(t *testing.T) {
+	//IsLegal works the move out without playing it,
+	//so check it against set on busy positions
+	var b, c Board
+	for _, suicide := range []bool{false, true} {
+		for _, rule := range []KoRule{SimpleKo, PositionalSuperko, SituationalSuperko} {
+			b.KoRule, b.AllowSuicide = rule, suicide
+			playout(&b, 300)
+			for i := range b.cells {
+				for _, black := range []bool{true, false} {
+					p := b.point(i)
+					_, reason := b.IsLegal(black, p.X, p.Y)
+					c.CopyFrom(&b)
+					if _, err := c.set(p.X, p.Y, black); err != reason {
+						t.Error("At", p, "expected", err, "got", reason)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestLegalConcurrent(t *testing.T) {
+	var b Board
+	b.KoRule = PositionalSuperko
+	playout(&b, 250)
+	moves := len(b.LegalMoves(true))
+	//Checking legality doesn't change the Board,
+	//so several goroutines may do it at once
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if n := len(b.LegalMoves(true)); n != moves {
+				t.Error("Expected", moves, "legal moves, got", n)
+			}
+			b.IsLegal(false, 9, 9)
+		}()
+	}
+	wg.Wait()
+}
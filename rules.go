@@ -0,0 +1,146 @@
+package baduk
+
+import "strings"
+
+//A Scoring method determines how
+//a finished Game is counted
+type Scoring int
+
+const (
+	//AreaScoring counts each color's stones plus
+	//the empty points they surround
+	AreaScoring Scoring = iota
+	//TerritoryScoring counts the empty points each
+	//color surrounds plus the prisoners it took
+	TerritoryScoring
+)
+
+//A Compensation determines how many points
+//White receives for Black's handicap stones
+type Compensation int
+
+const (
+	//NoCompensation gives White nothing
+	NoCompensation Compensation = iota
+	//CompensateHandicap gives White a point
+	//for each handicap stone
+	CompensateHandicap
+	//CompensateHandicapLess1 gives White a point
+	//for each handicap stone after the first
+	CompensateHandicapLess1
+)
+
+//Rules bundle the choices that differ between rulesets.
+//Ko and Suicide are applied to the Board of a Game,
+//Scoring, Komi and Compensation determine the count,
+//and if PassStones is true each pass hands a prisoner
+//to the opponent and White must pass last. Komi is for
+//even games, HandicapKomi replaces it with a handicap.
+type Rules struct {
+	Name         string
+	Ko           KoRule
+	Suicide      bool
+	Scoring      Scoring
+	Komi         float64
+	HandicapKomi float64
+	Compensation Compensation
+	PassStones   bool
+}
+
+//Commonly used rulesets
+var (
+	Chinese = Rules{
+		Name:         "Chinese",
+		Ko:           PositionalSuperko,
+		Scoring:      AreaScoring,
+		Komi:         7.5,
+		HandicapKomi: 0.5,
+		Compensation: CompensateHandicap,
+	}
+	Japanese = Rules{
+		Name:         "Japanese",
+		Ko:           SimpleKo,
+		Scoring:      TerritoryScoring,
+		Komi:         6.5,
+		HandicapKomi: 0.5,
+	}
+	AGA = Rules{
+		Name:         "AGA",
+		Ko:           SituationalSuperko,
+		Scoring:      AreaScoring,
+		Komi:         7.5,
+		HandicapKomi: 0.5,
+		Compensation: CompensateHandicapLess1,
+		PassStones:   true,
+	}
+	NewZealand = Rules{
+		Name:         "NZ",
+		Ko:           SituationalSuperko,
+		Suicide:      true,
+		Scoring:      AreaScoring,
+		Komi:         7,
+		HandicapKomi: 0.5,
+	}
+	//Ing komi is 8 with Black winning ties,
+	//which is the same as 7.5
+	Ing = Rules{
+		Name:         "Ing",
+		Ko:           SituationalSuperko,
+		Suicide:      true,
+		Scoring:      AreaScoring,
+		Komi:         7.5,
+		HandicapKomi: 0.5,
+		Compensation: CompensateHandicap,
+	}
+	TrompTaylor = Rules{
+		Name:         "Tromp-Taylor",
+		Ko:           PositionalSuperko,
+		Suicide:      true,
+		Scoring:      AreaScoring,
+		Komi:         7.5,
+		HandicapKomi: 0.5,
+	}
+)
+
+//Returns the commonly used ruleset called name,
+//ignoring case, as named in SGF and GTP. Also knows
+//"New Zealand", "GOE" for Ing and "TT" for Tromp-Taylor.
+func RulesByName(name string) (r Rules, ok bool) {
+	for _, c := range []Rules{Chinese, Japanese, AGA, NewZealand, Ing, TrompTaylor} {
+		if strings.EqualFold(c.Name, name) {
+			return c, true
+		}
+	}
+	switch strings.ToLower(name) {
+	case "new zealand":
+		return NewZealand, true
+	case "goe":
+		return Ing, true
+	case "tt", "tromptaylor":
+		return TrompTaylor, true
+	}
+	return
+}
+
+//Returns the points White receives
+//for a handicap of the given stones
+func (r Rules) HandicapCompensation(stones int) int {
+	if stones < 2 {
+		return 0
+	}
+	switch r.Compensation {
+	case CompensateHandicap:
+		return stones
+	case CompensateHandicapLess1:
+		return stones - 1
+	default:
+		return 0
+	}
+}
+
+//Applies the ko and suicide rules to a Board
+func (r Rules) apply(b *Board) {
+	b.KoRule = r.Ko
+	b.AllowSuicide = r.Suicide
+	return
+}
@@ -0,0 +1,76 @@
+package baduk
+
+import "testing"
+
+func TestRulesSuicide(t *testing.T) {
+	var g Game
+	//Black chain at 0,0 and 1,0 has no liberties
+	moves := []Point{{1, 0}, {2, 0}, {4, 4}, {1, 1}, {4, 3}, {0, 1}}
+	for _, r := range []Rules{Japanese, NewZealand} {
+		g.Rules = r
+		g.Init(5)
+		for _, m := range moves {
+			g.Play(m.X, m.Y)
+		}
+		err := g.Play(0, 0)
+		if r.Suicide && err != nil {
+			t.Error("Expected suicide under", r.Name, "rules, got", err)
+		}
+		if !r.Suicide && err != ErrSuicide {
+			t.Error("Expected ErrSuicide under", r.Name, "rules, got", err)
+		}
+	}
+	//Black's suicide hands white two prisoners
+	black, white := g.Prisoners()
+	if black != 0 || white != 2 {
+		t.Error("Expected prisoners black: 0, white: 2, got black:", black, ", white:", white)
+	}
+}
+
+func TestRulesPassStones(t *testing.T) {
+	var g Game
+	g.Rules = AGA
+	g.Init(9)
+	g.Play(4, 4)
+	g.Pass()
+	g.Pass()
+	//Black passed last, White must pass again
+	if g.Over() {
+		t.Error("Expected game to continue until White passes")
+	}
+	g.Pass()
+	if !g.Over() {
+		t.Error("Expected game over after White passes")
+	}
+	black, white := g.Prisoners()
+	if black != 2 || white != 1 {
+		t.Error("Expected pass stones black: 2, white: 1, got black:", black, ", white:", white)
+	}
+}
+
+func TestRulesScoring(t *testing.T) {
+	var g Game
+	for _, r := range []Rules{Chinese, Japanese} {
+		g.Rules = r
+		g.Init(4)
+		//Walls on columns 1 and 2, white captures a stone
+		moves := []Point{{1, 0}, {2, 0}, {1, 1}, {2, 1}, {1, 2}, {2, 2}, {1, 3}, {2, 3}, {3, 0}, {3, 1}}
+		for _, m := range moves {
+			g.Play(m.X, m.Y)
+		}
+		black, white := g.Score()
+		switch r.Scoring {
+		case AreaScoring:
+			if black != 8 || white != 8 {
+				t.Error("Expected area black: 8, white: 8, got black:", black, ", white:", white)
+			}
+		case TerritoryScoring:
+			if black != 4 || white != 4 {
+				t.Error("Expected territory black: 4, white: 4, got black:", black, ", white:", white)
+			}
+		}
+	}
+	if Chinese.HandicapCompensation(4) != 4 || AGA.HandicapCompensation(4) != 3 || Japanese.HandicapCompensation(4) != 0 {
+		t.Error("Unexpected handicap compensation")
+	}
+}
@@ -1,9 +1,6 @@
 package baduk
 
-import (
-	"strconv"
-	"sync"
-)
+import "strconv"
 
 //Scores the Board. Scoring follows this algorithm:
 //A color's score = total pieces + total empty pieces completely
@@ -15,182 +12,156 @@ func (b *Board) Score() (black, white int) {
 	if b.isEmpty() {
 		return
 	}
-	//Counts black/white/empty Pieces, sends down channels
-	blk, wht := make(chan int, 1), make(chan int, 1)
-	empty := make(chan Piece)
-	var wg sync.WaitGroup
-	wg.Add(1)
-	go b.countStones(blk, wht, empty, &wg)
-	//Do the counting
-	wg.Add(1)
-	go sumChan(blk, &black, &wg)
-	wg.Add(1)
-	go sumChan(wht, &white, &wg)
-	//mirror checkChain() from scoring.go, but focused on assembling empty chains
-	wg.Add(1)
-	go checkEmptyChains(blk, wht, empty, &wg)
-	wg.Wait()
+	s := getScratch()
+	black, white = b.score(s, nil)
+	putScratch(s)
 	return
 }
 
-//Counts stones, sends counts or Pieces down channels
-func (b *Board) countStones(blk chan int, wht chan int, empty chan Piece, wg *sync.WaitGroup) {
-	defer wg.Done()
-	for _, j := range b.Grid {
-		for _, i := range j {
-			if i.Black {
-				blk <- 1
-			}
-			if i.White {
-				wht <- 1
-			}
-			if i.Empty {
-				empty <- i
+//Scores the Board as Score does, with scratch s,
+//counting stones in the dead mask as empty
+func (b *Board) score(s *scratch, dead []bool) (black, white int) {
+	//One mark covers every empty region, so
+	//each is crawled once
+	s.newMark(len(b.cells))
+	region := s.stack
+	for i := range b.cells {
+		switch c := b.colorAt(i, dead); {
+		case c == blackStone:
+			black++
+		case c == whiteStone:
+			white++
+		case s.marked(i):
+			continue
+		default:
+			var bBord, wBord bool
+			region, bBord, wBord = b.crawlEmpty(s, i, region[:0], dead)
+			if bBord && !wBord {
+				black += len(region)
+			} else if wBord && !bBord {
+				white += len(region)
 			}
 		}
 	}
-	close(empty)
+	s.stack = region
 	return
 }
 
-//Takes channel, sums it, writes to total
-func sumChan(pipe chan int, total *int, wg *sync.WaitGroup) {
-	defer wg.Done()
-	sum := 0
-	for i := range pipe {
-		sum += i
-	}
-	*total = sum
-	return
+//Returns the number of stones captured by each color.
+//Stones lost to suicide count for the opponent.
+func (b *Board) Captures() (black, white int) {
+	return b.blackCaptures, b.whiteCaptures
 }
 
-//Assembles chains of Empty pieces
-func checkEmptyChains(blk chan int, wht chan int, empty chan Piece, wg *sync.WaitGroup) {
-	defer wg.Done()
-	chains := make([]map[Piece]bool, 0)
-	checked := make(map[Piece]bool)
-	for lib := range empty {
-		if checked[lib] {
-			continue
+//Scores the Board by territory, as in Japanese rules:
+//A color's score = total empty pieces completely enclosed
+//by said color + stones it captured + its opponent's stones
+//at the dead Points. Dead stones count as empty, without
+//being removed from the Board. Stones themselves don't count,
+//empty pieces bordered by both colors are neutral, and so are
+//the eyes of chains in seki.
+func (b *Board) ScoreTerritory(dead []Point) (black, white int) {
+	mask := b.deadMask(dead)
+	black, white = b.territory(mask)
+	//Eyes of chains in seki aren't territory
+	sb, sw := b.sekiEyes(mask)
+	black -= sb
+	white -= sw
+	black += b.blackCaptures
+	white += b.whiteCaptures
+	for i, d := range mask {
+		switch {
+		case !d:
+		case b.cells[i] == blackStone:
+			white++
+		default:
+			black++
 		}
-		//set up new WaitGroup to signal end of recursion
-		var ewg sync.WaitGroup
-		//Initialize chain channel
-		chainChan := make(chan map[Piece]bool, 1)
-		go func() { chainChan <- make(map[Piece]bool) }()
-		ewg.Add(1)
-		//crawls the empties, recursively
-		go emptyCrawler(lib, chainChan, &ewg)
-		ewg.Wait()
-		chain := <-chainChan
-		close(chainChan)
-		//adds chain to "checked"
-		for i, v := range chain {
-			checked[i] = v
-		}
-		//adds chain to chains
-		chains = append(chains, chain)
 	}
-	wg.Add(1)
-	go scoreEmptyChains(blk, wht, chains, wg)
 	return
 }
 
-func emptyCrawler(p Piece, chainChan chan map[Piece]bool, ewg *sync.WaitGroup) {
-	defer ewg.Done()
-	chain := <-chainChan
-	chain[p] = true
-	//copy chain to avoid concurrent reads
-	testChain := make(map[Piece]bool)
-	for k, v := range chain {
-		testChain[k] = v
-	}
-	chainChan <- chain
-	if p.Up != nil && p.Up.Empty && !testChain[*p.Up] {
-		ewg.Add(1)
-		go emptyCrawler(*p.Up, chainChan, ewg)
-	}
-	if p.Down != nil && p.Down.Empty && !testChain[*p.Down] {
-		ewg.Add(1)
-		go emptyCrawler(*p.Down, chainChan, ewg)
-	}
-	if p.Left != nil && p.Left.Empty && !testChain[*p.Left] {
-		ewg.Add(1)
-		go emptyCrawler(*p.Left, chainChan, ewg)
-	}
-	if p.Right != nil && p.Right.Empty && !testChain[*p.Right] {
-		ewg.Add(1)
-		go emptyCrawler(*p.Right, chainChan, ewg)
-	}
+//Scores the Board by area, as Score does, but
+//with the stones at the dead Points counted as
+//empty, so their area goes to the opponent.
+//Dead stones aren't removed from the Board.
+func (b *Board) ScoreArea(dead []Point) (black, white int) {
+	s := getScratch()
+	black, white = b.score(s, b.deadMask(dead))
+	putScratch(s)
 	return
 }
 
-//Checks empty area for scoring by assembling
-//empty chains then checking for border encapsulations
-func scoreEmptyChains(blk chan int, wht chan int, chains []map[Piece]bool, wg *sync.WaitGroup) {
-	defer wg.Done()
-	//Investigate empty chain's borders
-	for _, chain := range chains {
-		bBord, wBord := false, false
-		for lib := range chain {
-			bBord = bBord || lib.hasBlackBorder()
-			wBord = wBord || lib.hasWhiteBorder()
-			if bBord && wBord {
-				break
-			}
-		}
-		if bBord && wBord {
-			continue
-		} else if bBord {
-			blk <- len(chain)
-		} else if wBord {
-			wht <- len(chain)
+//Counts the empty Pieces enclosed by each color,
+//as Score does, but without the stones themselves.
+//Stones in the dead mask count as empty.
+func (b *Board) territory(dead []bool) (black, white int) {
+	s := getScratch()
+	black, white = b.score(s, dead)
+	putScratch(s)
+	for i := range b.cells {
+		switch b.colorAt(i, dead) {
+		case blackStone:
+			black--
+		case whiteStone:
+			white--
 		}
 	}
-	close(blk)
-	close(wht)
+	return
 }
 
-//Returns true if piece has adjacent Black piece
-func (p *Piece) hasBlackBorder() bool {
-	if p.Up != nil && p.Up.Black {
-		return true
-	} else if p.Down != nil && p.Down.Black {
-		return true
-	} else if p.Left != nil && p.Left.Black {
-		return true
-	} else if p.Right != nil && p.Right.Black {
-		return true
-	} else {
-		return false
+//Returns true if Board is empty
+func (b *Board) isEmpty() bool {
+	for _, c := range b.cells {
+		if c != empty {
+			return false
+		}
 	}
+	return true
 }
 
-//Returns true if piece has adjacent White piece
-func (p *Piece) hasWhiteBorder() bool {
-	if p.Up != nil && p.Up.White {
-		return true
-	} else if p.Down != nil && p.Down.White {
-		return true
-	} else if p.Left != nil && p.Left.White {
-		return true
-	} else if p.Right != nil && p.Right.White {
-		return true
-	} else {
-		return false
+//A Result is the final count of a game.
+//Black and White are the points each color scored,
+//without komi. Margin is the winner's lead after komi,
+//and Winner is "B", "W", or empty for a tie.
+type Result struct {
+	Black  int
+	White  int
+	Komi   float64
+	Margin float64
+	Winner string
+}
+
+//Makes a Result from the points each color
+//scored and komi for White
+func NewResult(black, white int, komi float64) (r Result) {
+	r = Result{Black: black, White: white, Komi: komi}
+	diff := float64(black) - float64(white) - komi
+	switch {
+	case diff > 0:
+		r.Winner = "B"
+		r.Margin = diff
+	case diff < 0:
+		r.Winner = "W"
+		r.Margin = -diff
 	}
+	return
 }
 
-//Returns true if Board is empty
-func (b *Board) isEmpty() bool {
-	for _, j := range b.Grid {
-		for _, i := range j {
-			if !i.Empty {
-				return false
-			}
-		}
+//Returns an SGF-style result, such as "W+6.5",
+//or "0" for a tie
+func (r Result) String() string {
+	if r.Winner == "" {
+		return "0"
 	}
-	return true
+	return r.Winner + "+" + strconv.FormatFloat(r.Margin, 'f', -1, 64)
+}
+
+//Scores the Board as Score does, then
+//adds komi for White to make a Result
+func (b *Board) ScoreKomi(komi float64) Result {
+	black, white := b.Score()
+	return NewResult(black, white, komi)
 }
 
 //Makes a string suitable for Sprintf output that
@@ -0,0 +1,204 @@
+package baduk
+
+//Finds chains in seki. A chain is in seki if it has
+//at least two liberties, some of them shared with the
+//opponent (empty pieces bordered by both colors), and
+//neither color can fill any shared liberty without
+//leaving its own chain with one liberty or less.
+//Seki is mutual: every opponent chain across a shared
+//liberty must be in seki too, and an eye on one side
+//only is a capturing race, not seki.
+//Returns the Points of each chain in seki.
+func (b *Board) Seki() (chains [][]Point) {
+	chains = b.seki(nil)
+	return
+}
+
+//A sekiChain is a chain considered for seki, with its
+//liberties, whether any of them is an eye (not shared),
+//and whether it's still in seki
+type sekiChain struct {
+	indexes []int
+	libs    []int
+	eye     bool
+	seki    bool
+}
+
+//Finds chains in seki as Seki does, with
+//stones in the dead mask counted as empty
+func (b *Board) seki(dead []bool) (chains [][]Point) {
+	//Empty pieces bordered by both colors are shared
+	shared := make([]bool, len(b.cells))
+	for _, r := range b.emptyChains(dead) {
+		if r.black && r.white {
+			for _, lib := range r.indexes {
+				shared[lib] = true
+			}
+		}
+	}
+	//ids holds the number of the chain at each
+	//stone, counting from 1, or 0 if there's none
+	ids := make([]int, len(b.cells))
+	var all []sekiChain
+	for i := range b.cells {
+		if b.colorAt(i, dead) == empty || ids[i] != 0 {
+			continue
+		}
+		c := sekiChain{indexes: b.wholeChain(i)}
+		for _, k := range c.indexes {
+			ids[k] = len(all) + 1
+		}
+		c.libs = b.liberties(c.indexes, dead)
+		for _, lib := range c.libs {
+			c.eye = c.eye || !shared[lib]
+		}
+		c.seki = b.inSeki(c.libs, b.cells[i] == blackStone, shared, dead)
+		all = append(all, c)
+	}
+	//Dropping a chain can break the seki of its
+	//opponents, so repeat until none are dropped
+	for dropped := true; dropped; {
+		dropped = false
+		for k := range all {
+			if all[k].seki && !b.mutualSeki(all, ids, k, shared) {
+				all[k].seki = false
+				dropped = true
+			}
+		}
+	}
+	for _, c := range all {
+		if c.seki {
+			chains = append(chains, b.chainPoints(c.indexes))
+		}
+	}
+	return
+}
+
+//Returns true if the chain with liberties libs is
+//in seki, given the shared empty pieces on the Board
+func (b *Board) inSeki(libs []int, black bool, shared []bool, dead []bool) bool {
+	if len(libs) < 2 {
+		return false
+	}
+	found := false
+	for _, lib := range libs {
+		if !shared[lib] {
+			continue
+		}
+		found = true
+		if b.libertiesAfter(lib, black, dead) > 1 || b.libertiesAfter(lib, !black, dead) > 1 {
+			return false
+		}
+	}
+	return found
+}
+
+//Returns true if every opponent chain across a shared
+//liberty of chain k is in seki, and has an eye only
+//if chain k has one
+func (b *Board) mutualSeki(all []sekiChain, ids []int, k int, shared []bool) bool {
+	c := all[k]
+	own := b.cells[c.indexes[0]]
+	for _, lib := range c.libs {
+		if !shared[lib] {
+			continue
+		}
+		for _, n := range b.adj[lib] {
+			if ids[n] == 0 || b.cells[n] == own {
+				continue
+			}
+			if o := all[ids[n]-1]; !o.seki || o.eye != c.eye {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+//Counts the liberties the chain at an empty index
+//would have if the given color played there.
+//A capture leaves room to live, so counts as two.
+func (b *Board) libertiesAfter(i int, black bool, dead []bool) int {
+	var libs []int
+	for _, n := range b.adj[i] {
+		switch b.colorAt(n, dead) {
+		case empty:
+			libs = append(libs, n)
+		case stone(black):
+			libs = append(libs, b.liberties(b.wholeChain(n), dead)...)
+		default:
+			if len(b.liberties(b.wholeChain(n), dead)) == 1 {
+				return 2
+			}
+		}
+	}
+	//Count each liberty once, but not i itself
+	s := getScratch()
+	s.newMark(len(b.cells))
+	s.setMark(i)
+	count := 0
+	for _, l := range libs {
+		if s.setMark(l) {
+			count++
+		}
+	}
+	putScratch(s)
+	return count
+}
+
+//Counts the empty pieces enclosed by each color
+//that are the eyes of chains in seki, with stones
+//in the dead mask counted as empty
+func (b *Board) sekiEyes(dead []bool) (black, white int) {
+	seki := make(map[Point]bool)
+	for _, chain := range b.seki(dead) {
+		for _, p := range chain {
+			seki[p] = true
+		}
+	}
+	if len(seki) == 0 {
+		return
+	}
+	for _, r := range b.emptyChains(dead) {
+		eye := false
+		for _, lib := range r.indexes {
+			for _, n := range b.adj[lib] {
+				eye = eye || seki[b.point(n)]
+			}
+		}
+		switch {
+		case !eye || r.black == r.white:
+			continue
+		case r.black:
+			black += len(r.indexes)
+		default:
+			white += len(r.indexes)
+		}
+	}
+	return
+}
+
+//A region is a chain of empty indexes, and
+//whether black or white stones border it
+type region struct {
+	indexes []int
+	black   bool
+	white   bool
+}
+
+//Assembles all chains of Empty pieces on the Board,
+//with stones in the dead mask counted as empty
+func (b *Board) emptyChains(dead []bool) (regions []region) {
+	s := getScratch()
+	s.newMark(len(b.cells))
+	for i := range b.cells {
+		if b.colorAt(i, dead) != empty || s.marked(i) {
+			continue
+		}
+		var r region
+		r.indexes, r.black, r.white = b.crawlEmpty(s, i, nil, dead)
+		regions = append(regions, r)
+	}
+	putScratch(s)
+	return
+}
@@ -0,0 +1,86 @@
+package baduk
+
+import "testing"
+
+//Sets up a seki on the top edge: black and white
+//chains each with one eye and a shared liberty at 2,0
+func setupSeki(b *Board) {
+	b.Init(5)
+	b.SetB(1, 0)
+	b.SetB(0, 1)
+	b.SetB(1, 1)
+	b.SetW(3, 0)
+	b.SetW(2, 1)
+	b.SetW(3, 1)
+	b.SetW(4, 1)
+	b.SetW(0, 2)
+	b.SetW(1, 2)
+	b.SetB(2, 2)
+	b.SetB(3, 2)
+	b.SetB(4, 2)
+	return
+}
+
+func TestSeki(t *testing.T) {
+	var b Board
+	setupSeki(&b)
+	seki := b.Seki()
+	if len(seki) != 2 {
+		t.Fatal("Expected 2 chains in seki, got", seki)
+	}
+	if len(seki[0]) != 3 || seki[0][0] != (Point{1, 0}) {
+		t.Error("Expected black chain at 1,0 in seki, got", seki[0])
+	}
+	if len(seki[1]) != 4 || seki[1][0] != (Point{3, 0}) {
+		t.Error("Expected white chain at 3,0 in seki, got", seki[1])
+	}
+	//Eyes of the seki chains aren't territory,
+	//and the lower half is still unsettled
+	black, white := b.ScoreTerritory(nil)
+	if black != 0 || white != 0 {
+		t.Error("Expected territory black: 0, white: 0, got black:", black, ", white:", white)
+	}
+	//...but count in area scoring
+	black, white = b.Score()
+	if black != 7 || white != 7 {
+		t.Error("Expected area black: 7, white: 7, got black:", black, ", white:", white)
+	}
+	//Once the shared liberty is filled, there's no seki
+	b.SetB(2, 0)
+	if seki = b.Seki(); len(seki) != 0 {
+		t.Error("Expected no seki, got", seki)
+	}
+}
+
+func TestNoSeki(t *testing.T) {
+	var b Board
+	//Facing walls with a gap are unfinished, not seki
+	b.Init(5)
+	for y := 0; y < 5; y++ {
+		b.SetB(1, y)
+		b.SetW(3, y)
+	}
+	if seki := b.Seki(); len(seki) != 0 {
+		t.Error("Expected no seki, got", seki)
+	}
+}
+
+func TestSemeaiNotSeki(t *testing.T) {
+	var b Board
+	//Black's eye beats white's outside liberty at 4,0,
+	//so this is a capturing race, not seki
+	b.Init(7)
+	for _, p := range []Point{{1, 0}, {0, 1}, {1, 1}, {2, 1}, {5, 0}, {5, 1}, {3, 2}, {4, 2}, {5, 2}} {
+		b.SetB(p.X, p.Y)
+	}
+	for _, p := range []Point{{3, 0}, {3, 1}, {4, 1}, {0, 2}, {1, 2}, {2, 2}} {
+		b.SetW(p.X, p.Y)
+	}
+	if seki := b.Seki(); len(seki) != 0 {
+		t.Error("Expected no seki, got", seki)
+	}
+	//Black's eye still counts as territory
+	if black, _ := b.ScoreTerritory(nil); black != 1 {
+		t.Error("Expected black territory 1, got", black)
+	}
+}
@@ -2,158 +2,234 @@ package baduk
 
 import (
 	"errors"
-	"sync"
+	"sort"
 )
 
-//Sets a Piece to either black or white
-func (b *Board) set(x int, y int, isBlack bool) (err error) {
+//ErrOutOfRange is returned when a move
+//is off the Board
+var ErrOutOfRange = errors.New("Point out of range")
+
+//ErrOccupied is returned when a move is
+//played on a Piece that isn't empty
+var ErrOccupied = errors.New("Piece is not empty")
+
+//ErrKo is returned when a move immediately
+//retakes a single stone ko
+var ErrKo = errors.New("Move retakes a ko")
+
+//ErrSuicide is returned when a move would leave
+//its own chain without liberties, unless suicide
+//is allowed by Board.AllowSuicide
+var ErrSuicide = errors.New("Move is suicide")
+
+//ErrSuperko is returned when a move repeats
+//a prior position forbidden by Board.KoRule
+var ErrSuperko = errors.New("Move repeats a prior position")
+
+//A MoveResult describes what placing a stone did.
+//Captured holds the Points of each chain removed,
+//SelfCapture is true if the chain removed was the
+//player's own, and Ko is the Point the opponent
+//can't retake on their next move, or nil.
+type MoveResult struct {
+	Captured    [][]Point
+	SelfCapture bool
+	Ko          *Point
+}
+
+//Returns the Points of all stones removed
+func (r MoveResult) Points() (pts []Point) {
+	for _, chain := range r.Captured {
+		pts = append(pts, chain...)
+	}
+	sortPoints(pts)
+	return
+}
+
+//Sets a Piece to either black or white,
+//returns what the move captured
+func (b *Board) set(x int, y int, isBlack bool) (res MoveResult, err error) {
 	if err = b.checkRange(x, y); err != nil {
-		return err
+		return
 	}
-	if !b.Grid[y][x].Empty {
-		err = errors.New("Piece is not empty")
+	i := y*b.Size + x
+	if b.cells[i] != empty {
+		err = ErrOccupied
 		return
 	}
-	b.Grid[y][x].Black = isBlack
-	b.Grid[y][x].White = !isBlack
-	b.Grid[y][x].Empty = false
+	if b.ko != nil && *b.ko == (Point{x, y}) && b.koBlack == isBlack {
+		err = ErrKo
+		return
+	}
+	b.cells[i] = stone(isBlack)
 	//Check if setting a piece captures opponent's adjacent chains
-	b.Grid[y][x].checkCapture(isBlack)
+	chains := b.checkCapture(i, stone(!isBlack))
+	captured := MoveResult{Captured: chains}.Points()
 	//If there are no liberties after checking opponent's chains,
-	//check whether opponent captures chains connected to this move
-	if !b.Grid[y][x].hasLiberty() {
-		b.Grid[y][x].checkChain(!isBlack)
+	//check whether opponent captures chains connected to this move.
+	//Nothing was captured in that case, so taking back the piece
+	//leaves the Board as it was. Single stone suicide is never allowed.
+	var suicided []Point
+	if !b.hasLiberty(i) {
+		chain, lib := b.crawlChain(&b.work, i, true, b.work.stack[:0])
+		b.work.stack = chain
+		if !lib {
+			if !b.AllowSuicide || len(chain) == 1 {
+				b.setE(x, y)
+				err = ErrSuicide
+				return
+			}
+			suicided = b.emptyChain(chain)
+		}
+	}
+	//Update the hash with the placed and removed stones
+	hash := b.hash ^ zobristKey(x, y, isBlack)
+	for _, c := range captured {
+		hash ^= zobristKey(c.X, c.Y, !isBlack)
+	}
+	for _, c := range suicided {
+		hash ^= zobristKey(c.X, c.Y, isBlack)
+	}
+	//If the position repeats, put everything back
+	if b.repeats(hash, !isBlack) {
+		b.fill(captured, !isBlack)
+		b.fill(suicided, isBlack)
+		b.setE(x, y)
+		err = ErrSuperko
+		return
+	}
+	b.hash = hash
+	b.history = append(b.history, situation{hash, !isBlack})
+	//Count prisoners, stones lost to suicide go to the opponent
+	if isBlack {
+		b.blackCaptures += len(captured)
+		b.whiteCaptures += len(suicided)
+	} else {
+		b.whiteCaptures += len(captured)
+		b.blackCaptures += len(suicided)
+	}
+	//Remember enough to undo the move
+	b.undo = append(b.undo, record{
+		black:    isBlack,
+		at:       Point{x, y},
+		captured: captured,
+		suicided: suicided,
+		ko:       b.ko,
+		koBlack:  b.koBlack,
+	})
+	b.redo = nil
+	//A single stone capturing a single stone,
+	//left with one liberty, makes a ko
+	b.ko = nil
+	if len(captured) == 1 && b.isKoShape(i) {
+		ko := captured[0]
+		b.ko = &ko
+		b.koBlack = !isBlack
+		//Copy, so the result can't change the Board
+		rko := ko
+		res.Ko = &rko
+	}
+	res.Captured = chains
+	if suicided != nil {
+		res.Captured = [][]Point{suicided}
+		res.SelfCapture = true
 	}
 	return
 }
 
-//Checks surrounding stones to see if
-//chains can be captured
-func (p *Piece) checkCapture(checkWhite bool) {
-	//Check chain liberties for each direction
-	//Only check if piece isn't border, matches checkWhite
-	//Or if it doesn't have any liberties
-	if p.Up != nil && !p.Up.Empty && p.Up.White == checkWhite && !p.Up.hasLiberty() {
-		p.Up.checkChain(checkWhite) //check chain liberties
-	}
-	if p.Down != nil && !p.Down.Empty && p.Down.White == checkWhite && !p.Down.hasLiberty() {
-		p.Down.checkChain(checkWhite) //check chain liberties
-	}
-	if p.Left != nil && !p.Left.Empty && p.Left.White == checkWhite && !p.Left.hasLiberty() {
-		p.Left.checkChain(checkWhite) //check chain liberties
-	}
-	if p.Right != nil && !p.Right.Empty && p.Right.White == checkWhite && !p.Right.hasLiberty() {
-		p.Right.checkChain(checkWhite) //check chain liberties
-	}
+//Returns true if the stone at index i has no adjacent
+//stones of its own color and exactly one liberty
+func (b *Board) isKoShape(i int) bool {
+	libs := 0
+	for _, n := range b.adj[i] {
+		switch b.cells[n] {
+		case empty:
+			libs++
+		case b.cells[i]:
+			return false
+		}
+	}
+	return libs == 1
+}
+
+//Records a pass by the given color,
+//which clears any ko
+func (b *Board) pass(isBlack bool) {
+	b.undo = append(b.undo, record{pass: true, black: isBlack, ko: b.ko, koBlack: b.koBlack})
+	b.redo = nil
+	b.ko = nil
+	b.history = append(b.history, situation{b.hash, !isBlack})
 	return
 }
 
-//If chain has no liberties, Empty it
-func (p *Piece) checkChain(checkWhite bool) {
-	chainChan := make(chan map[*Piece]bool, 1)
-	libChan := make(chan bool)
-	done := make(chan bool)
-	var wg sync.WaitGroup
-	//Initialize channel containing map representing chain
-	go func() { chainChan <- make(map[*Piece]bool) }()
-	//Crawl chain, use WaitGroup to detect when recursion finishes
-	wg.Add(1)
-	go crawler(p, checkWhite, chainChan, libChan, &wg)
-	//Send done signal if recursion is finished
-	go func() {
-		wg.Wait()
-		done <- true
-	}()
-	select {
-	//If no liberties found, "empty" all pieces in chain
-	case <-done:
-		emptyChain(<-chainChan)
-		return
-	//if liberty found, return
-	case <-libChan:
-		return
+//Sets Points to either black or white,
+//without checking for captures
+func (b *Board) fill(pts []Point, isBlack bool) {
+	for _, p := range pts {
+		b.cells[p.Y*b.Size+p.X] = stone(isBlack)
 	}
+	return
 }
 
-//Check up, down, left, right pieces (If not nil && same color as checkWhite)
-//if any adjacent checkWhite piece has liberties, quit without capturing
-//Otherwise, crawl to it, check its directions for liberties
-//Recursively crawl, but don't go to already crawled places in chainChan
-func crawler(pi *Piece, checkWhite bool, chainChan chan map[*Piece]bool, libChan chan bool, wg *sync.WaitGroup) {
-	defer wg.Done()
-	chain := <-chainChan
-	chain[pi] = true
-	//copy chain to avoid concurrent reads
-	testChain := make(map[*Piece]bool)
-	for k, v := range chain {
-		testChain[k] = v
-	}
-	chainChan <- chain
-	if pi.hasLiberty() {
-		libChan <- true
-		return
-	}
-	if pi.Up != nil && pi.Up.White == checkWhite && !testChain[pi.Up] {
-		wg.Add(1)
-		go crawler(pi.Up, checkWhite, chainChan, libChan, wg)
-	}
-	if pi.Down != nil && pi.Down.White == checkWhite && !testChain[pi.Down] {
-		wg.Add(1)
-		go crawler(pi.Down, checkWhite, chainChan, libChan, wg)
-	}
-	if pi.Left != nil && pi.Left.White == checkWhite && !testChain[pi.Left] {
-		wg.Add(1)
-		go crawler(pi.Left, checkWhite, chainChan, libChan, wg)
-	}
-	if pi.Right != nil && pi.Right.White == checkWhite && !testChain[pi.Right] {
-		wg.Add(1)
-		go crawler(pi.Right, checkWhite, chainChan, libChan, wg)
+//Checks surrounding stones to see if chains can
+//be captured, returns the Points of each captured chain
+func (b *Board) checkCapture(i int, check color) (captured [][]Point) {
+	//Check chain liberties for each direction
+	//Only check if it matches check
+	//And if it doesn't have any liberties
+	for _, n := range b.adj[i] {
+		if b.cells[n] == check && !b.hasLiberty(n) {
+			if chain := b.checkChain(n); chain != nil { //check chain liberties
+				captured = append(captured, chain)
+			}
+		}
 	}
 	return
 }
 
-//Empties chain represented by map[*Piece]bool
-func emptyChain(chain map[*Piece]bool) {
-	for p := range chain {
-		p.Black = false
-		p.White = false
-		p.Empty = true
+//If chain has no liberties, Empty it
+//and return the sorted Points emptied
+func (b *Board) checkChain(i int) (captured []Point) {
+	chain, lib := b.crawlChain(&b.work, i, true, b.work.stack[:0])
+	b.work.stack = chain
+	if !lib {
+		captured = b.emptyChain(chain)
 	}
 	return
 }
 
-//Returns true if Piece has liberties
-func (p *Piece) hasLiberty() bool {
-	if p.Up != nil && p.Up.Empty {
-		return true
-	}
-	if p.Down != nil && p.Down.Empty {
-		return true
-	}
-	if p.Left != nil && p.Left.Empty {
-		return true
-	}
-	if p.Right != nil && p.Right.Empty {
-		return true
+//Returns true if index i has liberties
+func (b *Board) hasLiberty(i int) bool {
+	for _, n := range b.adj[i] {
+		if b.cells[n] == empty {
+			return true
+		}
 	}
 	return false
 }
 
+//Sorts Points top to bottom, left to right
+func sortPoints(pts []Point) {
+	sort.Slice(pts, func(i, j int) bool {
+		if pts[i].Y != pts[j].Y {
+			return pts[i].Y < pts[j].Y
+		}
+		return pts[i].X < pts[j].X
+	})
+	return
+}
+
 //Sets a Piece to empty
 func (b *Board) setE(x, y int) {
-	b.Grid[y][x].Black = false
-	b.Grid[y][x].White = false
-	b.Grid[y][x].Empty = true
+	b.cells[y*b.Size+x] = empty
 	return
 }
 
 //Checks x,y against size
 func (b *Board) checkRange(x, y int) error {
 	switch {
-	case x < 0 || x >= b.Size:
-		return errors.New("x out of range")
-	case y < 0 || y >= b.Size:
-		return errors.New("y out of range")
+	case x < 0 || x >= b.Size, y < 0 || y >= b.Size:
+		return ErrOutOfRange
 	default:
 		return nil
 	}
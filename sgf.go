@@ -0,0 +1,553 @@
+package baduk
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+//An SGFError reports a malformed SGF record, or an
+//illegal move or setup in it, with the line and column
+//where it was found, counted from 1. Err is the
+//underlying error, such as ErrKo, if any.
+type SGFError struct {
+	Line int
+	Col  int
+	Msg  string
+	Err  error
+}
+
+func (e *SGFError) Error() string {
+	return "SGF line " + strconv.Itoa(e.Line) + ", column " + strconv.Itoa(e.Col) + ": " + e.Msg
+}
+
+//Returns the underlying error
+func (e *SGFError) Unwrap() error {
+	return e.Err
+}
+
+//GameInfo holds details of a Game that don't
+//affect play, as recorded in SGF
+type GameInfo struct {
+	PlayerBlack string
+	PlayerWhite string
+	//Result as recorded, such as "W+6.5" or "B+R"
+	Result string
+}
+
+//Returns an SGFError at the Property
+func (p *Property) fail(msg string, err error) *SGFError {
+	return &SGFError{p.line, p.col, msg, err}
+}
+
+//Parses SGF text, tracking the line and column
+type sgfParser struct {
+	s    string
+	i    int
+	line int
+	col  int
+}
+
+//Parses the game trees of an SGF collection, returns
+//the root Node of each. A malformed collection returns
+//an *SGFError.
+func ParseSGF(s string) (trees []*Node, err error) {
+	p := &sgfParser{s: s, line: 1, col: 1}
+	for {
+		p.skipSpace()
+		if p.i == len(p.s) {
+			break
+		}
+		var root *Node
+		if root, err = p.tree(); err != nil {
+			return
+		}
+		trees = append(trees, root)
+	}
+	if len(trees) == 0 {
+		err = p.fail("No game tree found")
+	}
+	return
+}
+
+//Returns an SGFError at the current position
+func (p *sgfParser) fail(msg string) *SGFError {
+	return &SGFError{p.line, p.col, msg, nil}
+}
+
+//Returns the next byte without consuming it,
+//or 0 at the end
+func (p *sgfParser) peek() byte {
+	if p.i == len(p.s) {
+		return 0
+	}
+	return p.s[p.i]
+}
+
+//Consumes the next byte
+func (p *sgfParser) next() (c byte) {
+	c = p.s[p.i]
+	p.i++
+	if c == '\n' {
+		p.line++
+		p.col = 1
+	} else {
+		p.col++
+	}
+	return
+}
+
+//Skips white space between tokens
+func (p *sgfParser) skipSpace() {
+	for p.i < len(p.s) {
+		switch p.s[p.i] {
+		case ' ', '\t', '\n', '\r', '\v', '\f':
+			p.next()
+		default:
+			return
+		}
+	}
+	return
+}
+
+//Parses a game tree: "(" Sequence GameTree* ")".
+//The nodes of the sequence are chained as children,
+//and the subtrees become children of the last one.
+func (p *sgfParser) tree() (root *Node, err error) {
+	if p.peek() != '(' {
+		err = p.fail("Expected '('")
+		return
+	}
+	p.next()
+	var last *Node
+	for p.skipSpace(); p.peek() == ';'; p.skipSpace() {
+		var n *Node
+		if n, err = p.node(); err != nil {
+			return
+		}
+		if last == nil {
+			root = n
+		} else {
+			last.AddChild(n)
+		}
+		last = n
+	}
+	if root == nil {
+		err = p.fail("Expected ';'")
+		return
+	}
+	for p.peek() == '(' {
+		var sub *Node
+		if sub, err = p.tree(); err != nil {
+			return
+		}
+		last.AddChild(sub)
+		p.skipSpace()
+	}
+	if p.peek() != ')' {
+		err = p.fail("Expected ')'")
+		return
+	}
+	p.next()
+	return
+}
+
+//Parses a node: ";" Property*
+func (p *sgfParser) node() (n *Node, err error) {
+	p.next()
+	n = new(Node)
+	for p.skipSpace(); isLetter(p.peek()); p.skipSpace() {
+		prop := Property{line: p.line, col: p.col}
+		//Lowercase letters of old FF[3] identifiers are dropped
+		for isLetter(p.peek()) {
+			if c := p.next(); c >= 'A' && c <= 'Z' {
+				prop.ID += string(c)
+			}
+		}
+		if prop.ID == "" {
+			err = &SGFError{prop.line, prop.col, "Property identifier has no uppercase letters", nil}
+			return
+		}
+		for p.skipSpace(); p.peek() == '['; p.skipSpace() {
+			var v string
+			if v, err = p.value(); err != nil {
+				return
+			}
+			prop.Values = append(prop.Values, v)
+		}
+		if len(prop.Values) == 0 {
+			err = p.fail("Expected '[' after " + prop.ID)
+			return
+		}
+		n.Props = append(n.Props, prop)
+	}
+	return
+}
+
+//Parses a value: "[" text "]". A backslash escapes
+//the next character, and a backslash before a line
+//break removes both.
+func (p *sgfParser) value() (v string, err error) {
+	line, col := p.line, p.col
+	p.next()
+	var buf []byte
+	for {
+		if p.i == len(p.s) {
+			err = &SGFError{line, col, "Value is missing ']'", nil}
+			return
+		}
+		c := p.next()
+		switch {
+		case c == ']':
+			v = string(buf)
+			return
+		case c == '\\' && p.i < len(p.s):
+			c = p.next()
+			//Soft line break, with \r\n or \n\r
+			if c == '\n' || c == '\r' {
+				if d := p.peek(); (d == '\n' || d == '\r') && d != c {
+					p.next()
+				}
+				continue
+			}
+		}
+		buf = append(buf, c)
+	}
+}
+
+//Returns true if c is an ASCII letter
+func isLetter(c byte) bool {
+	return (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z')
+}
+
+//Reads an SGF point such as "dd" on a Board of size,
+//an empty value, or "tt" on sizes up to 19, is a pass
+func sgfPoint(v string, size int) (pt Point, pass bool, err error) {
+	if v == "" || (v == "tt" && size <= 19) {
+		pass = true
+		return
+	}
+	if len(v) != 2 || v[0] < 'a' || v[0] > 'z' || v[1] < 'a' || v[1] > 'z' {
+		err = errors.New("Point " + strconv.Quote(v) + " is not valid")
+		return
+	}
+	pt = Point{int(v[0] - 'a'), int(v[1] - 'a')}
+	if pt.X >= size || pt.Y >= size {
+		err = ErrOutOfRange
+	}
+	return
+}
+
+//Reads the points of a setup property, where a value
+//may be a rectangle compressed as "aa:cc", from its
+//upper left corner to its lower right
+func (p *Property) points(size int) (pts []Point, err *SGFError) {
+	for _, v := range p.Values {
+		corners := strings.SplitN(v, ":", 2)
+		var lo, hi Point
+		var pass bool
+		var e error
+		if lo, pass, e = sgfPoint(corners[0], size); e == nil && pass {
+			e = errors.New("Setup point is empty")
+		}
+		hi = lo
+		if e == nil && len(corners) == 2 {
+			if hi, pass, e = sgfPoint(corners[1], size); e == nil && pass {
+				e = errors.New("Setup point is empty")
+			}
+		}
+		if e == nil && (hi.X < lo.X || hi.Y < lo.Y) {
+			e = errors.New("Rectangle " + strconv.Quote(v) + " is reversed")
+		}
+		if e != nil {
+			err = p.fail(e.Error(), e)
+			return
+		}
+		for y := lo.Y; y <= hi.Y; y++ {
+			for x := lo.X; x <= hi.X; x++ {
+				pts = append(pts, Point{x, y})
+			}
+		}
+	}
+	return
+}
+
+//Loads a Game from an SGF record, replaying the
+//main line of its first game tree. Reads the size (SZ),
+//setup stones (AB, AW, AE), moves (B, W), player names
+//(PB, PW), komi (KM), handicap (HA), rules (RU),
+//result (RE) and move comments (C). Unknown rules
+//leave Game.Rules as they are. Setup stones before
+//the first move make the starting position; with a
+//handicap, the black ones are the handicap stones.
+//A malformed record or an illegal move returns
+//an *SGFError.
+func (g *Game) LoadSGF(sgf string) (err error) {
+	trees, err := ParseSGF(sgf)
+	if err != nil {
+		return
+	}
+	err = g.loadLine(trees[0].mainLine())
+	return
+}
+
+//Sets up the Game from the root of a line
+//of Nodes, then replays the whole line
+func (g *Game) loadLine(line []*Node) (err error) {
+	if err = g.loadRoot(line[0]); err != nil {
+		return
+	}
+	for _, n := range line {
+		if err = g.loadNode(n); err != nil {
+			return
+		}
+	}
+	return
+}
+
+//Loads the position at the end of the main line of
+//an SGF record onto the Board, as Game.LoadSGF does
+func (b *Board) LoadSGF(sgf string) (err error) {
+	var g Game
+	if err = g.LoadSGF(sgf); err != nil {
+		return
+	}
+	b.CopyFrom(&g.Board)
+	return
+}
+
+//Reads the root properties that set up the
+//Game: rules, size, komi and game info
+func (g *Game) loadRoot(root *Node) (err error) {
+	if p := root.property("RU"); p != nil {
+		if r, ok := RulesByName(p.Values[0]); ok {
+			g.Rules = r
+		}
+	}
+	size := 19
+	if p := root.property("SZ"); p != nil {
+		//Rectangular boards are written "19:13"
+		if size, err = strconv.Atoi(p.Values[0]); err != nil {
+			err = p.fail("Size "+strconv.Quote(p.Values[0])+" is not supported", err)
+			return
+		}
+	}
+	if err = g.Init(size); err != nil {
+		if p := root.property("SZ"); p != nil {
+			err = p.fail(err.Error(), err)
+		}
+		return
+	}
+	if p := root.property("KM"); p != nil {
+		if g.Komi, err = strconv.ParseFloat(strings.TrimSpace(p.Values[0]), 64); err != nil {
+			err = p.fail("Komi "+strconv.Quote(p.Values[0])+" is not a number", err)
+			return
+		}
+	}
+	g.Info = GameInfo{}
+	if p := root.property("PB"); p != nil {
+		g.Info.PlayerBlack = p.Values[0]
+	}
+	if p := root.property("PW"); p != nil {
+		g.Info.PlayerWhite = p.Values[0]
+	}
+	if p := root.property("RE"); p != nil {
+		g.Info.Result = p.Values[0]
+	}
+	return
+}
+
+//Applies the setup and move properties of a node
+func (g *Game) loadNode(n *Node) (err error) {
+	size := g.Board.Size
+	setup := false
+	for i := range n.Props {
+		p := &n.Props[i]
+		switch p.ID {
+		case "AB", "AW", "AE":
+			if len(g.Moves) > 0 {
+				return p.fail("Setup after moves is not supported", nil)
+			}
+			pts, e := p.points(size)
+			if e != nil {
+				return e
+			}
+			for _, pt := range pts {
+				switch p.ID {
+				case "AB":
+					g.Board.fill([]Point{pt}, true)
+				case "AW":
+					g.Board.fill([]Point{pt}, false)
+				default:
+					g.Board.setE(pt.X, pt.Y)
+				}
+			}
+			setup = true
+		case "PL":
+			g.whiteToPlay = strings.ToUpper(p.Values[0]) == "W"
+		}
+	}
+	if setup {
+		g.loadSetup(n)
+	}
+	b, w := n.property("B"), n.property("W")
+	if b != nil && w != nil {
+		return w.fail("Node has both B and W moves", nil)
+	}
+	p := b
+	if p == nil {
+		p = w
+	}
+	if p == nil {
+		return
+	}
+	pt, pass, e := sgfPoint(p.Values[0], size)
+	if e != nil {
+		return p.fail(e.Error(), e)
+	}
+	if pass {
+		g.pass(p.ID == "B")
+		g.loadComment(n)
+		return
+	}
+	if e = g.play(p.ID == "B", pt.X, pt.Y); e != nil {
+		return p.fail("Illegal move "+p.ID+"["+p.Values[0]+"]: "+e.Error(), e)
+	}
+	g.loadComment(n)
+	return
+}
+
+//Keeps a node's comment with its move
+func (g *Game) loadComment(n *Node) {
+	if c := n.property("C"); c != nil && len(g.Moves) > 0 {
+		g.Moves[len(g.Moves)-1].Comment = c.Values[0]
+	}
+	return
+}
+
+//Restarts the Board's history from the setup
+//position, and takes a handicap from the setup
+//stones: with HA, black setup stones are the
+//handicap and White plays next
+func (g *Game) loadSetup(n *Node) {
+	if ha := n.property("HA"); ha != nil {
+		if stones, _ := strconv.Atoi(ha.Values[0]); stones >= 2 {
+			g.Handicap = nil
+			for i, c := range g.Board.cells {
+				if c == blackStone {
+					g.Handicap = append(g.Handicap, g.Board.point(i))
+				}
+			}
+			g.whiteToPlay = n.property("PL") == nil || strings.ToUpper(n.property("PL").Values[0]) == "W"
+		}
+	}
+	g.Board.resetHistory(!g.whiteToPlay)
+	return
+}
+
+//Writes the Game as an SGF record: the size, komi,
+//rules, player names and result, the starting position,
+//with any handicap, as setup stones, then every move
+//with its comment. The result is Game.Info.Result, or
+//the agreed Result if the Game is over and scored.
+func (g *Game) SGF() (sgf string) {
+	sgf = "(;FF[4]GM[1]CA[UTF-8]SZ[" + strconv.Itoa(g.Board.Size) + "]"
+	sgf += "KM[" + strconv.FormatFloat(g.Komi, 'f', -1, 64) + "]"
+	if g.Rules.Name != "" {
+		sgf += "RU[" + sgfText(g.Rules.Name) + "]"
+	}
+	if g.Info.PlayerBlack != "" {
+		sgf += "PB[" + sgfText(g.Info.PlayerBlack) + "]"
+	}
+	if g.Info.PlayerWhite != "" {
+		sgf += "PW[" + sgfText(g.Info.PlayerWhite) + "]"
+	}
+	switch {
+	case g.Info.Result != "":
+		sgf += "RE[" + sgfText(g.Info.Result) + "]"
+	case g.Agreed():
+		sgf += "RE[" + g.Result().String() + "]"
+	}
+	if len(g.Handicap) > 0 {
+		sgf += "HA[" + strconv.Itoa(len(g.Handicap)) + "]"
+	}
+	//The starting position is what's left
+	//after taking back every move
+	start := g.Board.Clone()
+	for range g.Moves {
+		start.Undo()
+	}
+	sgf += start.setupSGF()
+	//White plays first after a handicap, say
+	//so if it's the other way around
+	first := g.BlackToPlay()
+	if len(g.Moves) > 0 {
+		first = g.Moves[0].Black
+	}
+	switch {
+	case first && len(g.Handicap) > 0:
+		sgf += "PL[B]"
+	case !first && len(g.Handicap) == 0:
+		sgf += "PL[W]"
+	}
+	for i, m := range g.Moves {
+		if i%10 == 0 {
+			sgf += "\n"
+		}
+		color := "W"
+		if m.Black {
+			color = "B"
+		}
+		sgf += ";" + color + "["
+		if !m.Pass {
+			sgf += sgfCoord(Point{m.X, m.Y})
+		}
+		sgf += "]"
+		if m.Comment != "" {
+			sgf += "C[" + sgfText(m.Comment) + "]"
+		}
+	}
+	sgf += ")\n"
+	return
+}
+
+//Writes the position on the Board as an SGF record
+//of setup stones, with its size and no moves
+func (b *Board) SGF() (sgf string) {
+	sgf = "(;FF[4]GM[1]CA[UTF-8]SZ[" + strconv.Itoa(b.Size) + "]"
+	sgf += b.setupSGF()
+	sgf += ")\n"
+	return
+}
+
+//Returns the black and white stones on the
+//Board as AB and AW properties
+func (b *Board) setupSGF() (sgf string) {
+	var blk, wht string
+	for i, c := range b.cells {
+		switch c {
+		case blackStone:
+			blk += "[" + sgfCoord(b.point(i)) + "]"
+		case whiteStone:
+			wht += "[" + sgfCoord(b.point(i)) + "]"
+		}
+	}
+	if blk != "" {
+		sgf += "AB" + blk
+	}
+	if wht != "" {
+		sgf += "AW" + wht
+	}
+	return
+}
+
+//Returns the SGF coordinates of a Point, such as "dd"
+func sgfCoord(p Point) string {
+	return string([]byte{byte('a' + p.X), byte('a' + p.Y)})
+}
+
+//Escapes text for an SGF value
+func sgfText(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, "]", `\]`)
+	return r.Replace(s)
+}
@@ -0,0 +1,157 @@
+package baduk
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestLoadSGF(t *testing.T) {
+	var g Game
+	sgf := `(;FF[4]GM[1]SZ[5]KM[0.5]RU[Japanese]PB[Black \] Player]PW[White]RE[B+0.5]
+;B[ba];W[ca];B[ab];W[bb];B[cb];W[aa]
+;B[da]
+(;W[tt];B[])(;W[ee]))`
+	if err := g.LoadSGF(sgf); err != nil {
+		t.Fatal("Error loading SGF:", err)
+	}
+	if g.Board.Size != 5 || g.Komi != 0.5 || g.Rules.Name != "Japanese" {
+		t.Error("Expected 5x5 Japanese with komi 0.5, got", g.Board.Size, g.Rules.Name, g.Komi)
+	}
+	if g.Info.PlayerBlack != "Black ] Player" || g.Info.PlayerWhite != "White" || g.Info.Result != "B+0.5" {
+		t.Error("Expected game info, got", g.Info)
+	}
+	//White's throw-in at aa captures ba, taking a ko.
+	//The main line ends in passes.
+	if len(g.Moves) != 9 || !g.Over() {
+		t.Error("Expected 9 moves ending in two passes, got", len(g.Moves))
+	}
+	if c := g.Moves[5].Captured; len(c) != 1 || c[0][0] != (Point{1, 0}) {
+		t.Error("Expected W[aa] to capture ba, got", c)
+	}
+	if g.Board.cells[0] != whiteStone || g.Board.cells[1] != empty || g.Board.cells[3] != blackStone {
+		t.Error("Expected white at aa, ba empty, black at da")
+		t.Log(g.Board.PrettyString())
+	}
+	//A Board gets the final position
+	var b Board
+	if err := b.LoadSGF(sgf); err != nil || b.MoveNumber() != 9 || b.cells[3] != blackStone {
+		t.Error("Expected Board at the end of the main line, got", err)
+	}
+}
+
+func TestLoadSGFSetup(t *testing.T) {
+	var g Game
+	//Handicap stones, and a compressed rectangle of white stones
+	sgf := "(;SZ[9]HA[2]AB[cg][gc]AW[aa:bb]AE[ab]\n;W[ee])"
+	if err := g.LoadSGF(sgf); err != nil {
+		t.Fatal("Error loading SGF:", err)
+	}
+	if len(g.Handicap) != 2 || g.Handicap[0] != (Point{6, 2}) || g.Handicap[1] != (Point{2, 6}) {
+		t.Error("Expected handicap at gc and cg, got", g.Handicap)
+	}
+	want := map[Point]color{{0, 0}: whiteStone, {1, 0}: whiteStone, {0, 1}: empty, {1, 1}: whiteStone, {4, 4}: whiteStone}
+	for p, c := range want {
+		if g.Board.cells[p.Y*9+p.X] != c {
+			t.Error("Expected", c, "at", p)
+		}
+	}
+	if len(g.Moves) != 1 || !g.BlackToPlay() {
+		t.Error("Expected White to move first, then Black")
+	}
+	//Undo can't take back the setup
+	g.Undo()
+	if err := g.Undo(); err != ErrNoUndo || g.Board.cells[0] != whiteStone {
+		t.Error("Expected setup to stay, got", err)
+	}
+}
+
+func TestLoadSGFErrors(t *testing.T) {
+	cases := []struct {
+		sgf       string
+		line, col int
+		err       error
+	}{
+		{"", 1, 1, nil},
+		{"(;SZ[5]\n;B[cc\n", 2, 3, nil},
+		{"(;SZ[5];B[cc]\n  ;W[cc])", 2, 4, ErrOccupied},
+		{"(;SZ[5];B[ff])", 1, 9, ErrOutOfRange},
+		{"(;SZ[3])", 1, 3, nil},
+		{"(;SZ[5];B[aa]W[bb])", 1, 14, nil},
+		{"(;SZ[5];B[aa];W[bb];AB[cc])", 1, 21, nil},
+		{"(;SZ[5];B[aa] x)", 1, 15, nil},
+		{"(;SZ[5];AB[aa:])", 1, 9, nil},
+		{"(;SZ[5];AW[cc:aa])", 1, 9, nil},
+	}
+	for _, c := range cases {
+		var g Game
+		err := g.LoadSGF(c.sgf)
+		var se *SGFError
+		if !errors.As(err, &se) {
+			t.Error("Expected SGFError for", c.sgf, "got", err)
+			continue
+		}
+		if se.Line != c.line || se.Col != c.col || (c.err != nil && !errors.Is(err, c.err)) {
+			t.Error("Expected error at", c.line, c.col, c.err, "for", c.sgf, "got", err)
+		}
+	}
+}
+
+func TestSGF(t *testing.T) {
+	var g Game
+	g.Rules = AGA
+	g.Init(9)
+	g.SetHandicap(2)
+	g.Info = GameInfo{PlayerBlack: `Ann \o/`, PlayerWhite: "Bo [3d]"}
+	g.Play(4, 4)
+	g.Moves[0].Comment = "Tengen [center]"
+	g.Play(2, 2)
+	g.Play(6, 6)
+	//With AGA rules, White passes last
+	g.Pass()
+	g.Pass()
+	g.Agree(true)
+	g.Agree(false)
+	want := "(;FF[4]GM[1]CA[UTF-8]SZ[9]KM[0.5]RU[AGA]PB[Ann \\\\o/]PW[Bo [3d\\]]RE[" + g.Result().String() + "]HA[2]AB[gc][cg]" +
+		"\n;W[ee]C[Tengen [center\\]];B[cc];W[gg];B[];W[])\n"
+	if got := g.SGF(); got != want {
+		t.Error("Expected", want, "got", got)
+	}
+	//Loading it back gives the same Game
+	var h Game
+	if err := h.LoadSGF(g.SGF()); err != nil {
+		t.Fatal("Error loading SGF:", err)
+	}
+	info := g.Info
+	info.Result = g.Result().String()
+	if h.Info != info || h.Rules.Name != "AGA" || h.Komi != 0.5 {
+		t.Error("Expected game info to round trip, got", h.Info, h.Rules.Name, h.Komi)
+	}
+	if len(h.Handicap) != 2 || len(h.Moves) != 5 || h.Moves[0].Comment != "Tengen [center]" || h.Moves[0].Black {
+		t.Error("Expected handicap and moves to round trip, got", h.Handicap, h.Moves)
+	}
+	if h.SGF() != g.SGF() {
+		t.Error("Expected the same SGF, got", h.SGF())
+	}
+}
+
+func TestBoardSGF(t *testing.T) {
+	var b Board
+	b.Init(5)
+	b.SetB(0, 0)
+	b.SetW(1, 0)
+	b.SetB(2, 2)
+	want := "(;FF[4]GM[1]CA[UTF-8]SZ[5]AB[aa][cc]AW[ba])\n"
+	if got := b.SGF(); got != want {
+		t.Error("Expected", want, "got", got)
+	}
+	var c Board
+	if err := c.LoadSGF(b.SGF()); err != nil || c.SGF() != want {
+		t.Error("Expected the position to round trip, got", err)
+	}
+	//A Game with no handicap but White first says so
+	var g Game
+	g.LoadSGF("(;SZ[5]PL[W];W[cc])")
+	if got := g.SGF(); got != "(;FF[4]GM[1]CA[UTF-8]SZ[5]KM[0]PL[W]\n;W[cc])\n" {
+		t.Error("Expected PL[W], got", got)
+	}
+}
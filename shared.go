@@ -0,0 +1,123 @@
+package baduk
+
+import (
+	"errors"
+	"sync"
+)
+
+//ErrStale is returned when a change is made against
+//a version of a SharedBoard that's no longer current
+var ErrStale = errors.New("Board has changed since that version")
+
+//A SharedBoard guards a Board so several goroutines,
+//such as request handlers for the same game, can use it.
+//Every change bumps its version, and changes are only
+//made against the current version, so two handlers that
+//read the same position can't both move from it. Reads
+//share the lock, so they can run at once. The zero value
+//is an empty Board at version 0; call Init or Load first.
+type SharedBoard struct {
+	mu      sync.RWMutex
+	board   Board
+	version uint64
+}
+
+//Initializes an empty Board of size, bumping the version
+func (s *SharedBoard) Init(size int) (version uint64, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err = s.board.Init(size); err != nil {
+		return
+	}
+	s.version++
+	version = s.version
+	return
+}
+
+//Replaces the Board with a copy of b, bumping the version
+func (s *SharedBoard) Load(b *Board) (version uint64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.board.CopyFrom(b)
+	s.version++
+	version = s.version
+	return
+}
+
+//Returns the current version
+func (s *SharedBoard) Version() uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.version
+}
+
+//Returns a copy of the Board and its version,
+//which the caller may read and change freely
+func (s *SharedBoard) Snapshot() (b *Board, version uint64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	b = s.board.Clone()
+	version = s.version
+	return
+}
+
+//Calls f with the Board under a shared lock, to read
+//it without copying. f may run alongside other readers,
+//so it must not play, pass, undo or otherwise change
+//the Board, and must not keep it.
+func (s *SharedBoard) View(f func(b *Board)) (version uint64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	f(&s.board)
+	version = s.version
+	return
+}
+
+//Calls f with the Board under the lock if version
+//is current, otherwise returns ErrStale. If f returns
+//nil, the version is bumped and the new one returned.
+//If f returns an error, it must leave the Board as it was.
+func (s *SharedBoard) Update(version uint64, f func(b *Board) error) (next uint64, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	next = s.version
+	if version != s.version {
+		err = ErrStale
+		return
+	}
+	if err = f(&s.board); err != nil {
+		return
+	}
+	s.version++
+	next = s.version
+	return
+}
+
+//Places a stone as Board.Play does,
+//if version is current
+func (s *SharedBoard) Play(version uint64, isBlack bool, x, y int) (res MoveResult, next uint64, err error) {
+	next, err = s.Update(version, func(b *Board) (err error) {
+		res, err = b.Play(isBlack, x, y)
+		return
+	})
+	return
+}
+
+//Passes for the given color,
+//if version is current
+func (s *SharedBoard) Pass(version uint64, isBlack bool) (next uint64, err error) {
+	next, err = s.Update(version, func(b *Board) (err error) {
+		b.Pass(isBlack)
+		return
+	})
+	return
+}
+
+//Takes back the last move as Board.Undo does,
+//if version is current
+func (s *SharedBoard) Undo(version uint64) (next uint64, err error) {
+	next, err = s.Update(version, func(b *Board) error {
+		return b.Undo()
+	})
+	return
+}
@@ -0,0 +1,71 @@
+package baduk
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSharedBoard(t *testing.T) {
+	var s SharedBoard
+	v, err := s.Init(9)
+	if err != nil || v != 1 {
+		t.Fatal("Expected version 1, got", v, err)
+	}
+	_, v, err = s.Play(v, true, 4, 4)
+	if err != nil || v != 2 {
+		t.Error("Expected version 2, got", v, err)
+	}
+	//A move against the old version is refused
+	if _, next, err := s.Play(1, false, 3, 3); err != ErrStale || next != 2 {
+		t.Error("Expected ErrStale at version 2, got", next, err)
+	}
+	//Illegal moves don't bump the version
+	if _, next, err := s.Play(v, false, 4, 4); err != ErrOccupied || next != 2 {
+		t.Error("Expected ErrOccupied at version 2, got", next, err)
+	}
+	if v, err = s.Pass(v, false); err != nil || v != 3 {
+		t.Error("Expected version 3, got", v, err)
+	}
+	//Snapshots are copies
+	b, sv := s.Snapshot()
+	b.SetW(0, 0)
+	s.View(func(b *Board) {
+		if b.cells[0] != empty || b.MoveNumber() != 2 {
+			t.Error("Expected snapshot changes to stay out of the shared Board")
+		}
+	})
+	if sv != v {
+		t.Error("Expected snapshot version", v, "got", sv)
+	}
+	if v, err = s.Undo(v); err != nil || v != 4 {
+		t.Error("Expected version 4, got", v, err)
+	}
+}
+
+func TestSharedBoardRace(t *testing.T) {
+	var s SharedBoard
+	v, _ := s.Init(9)
+	//Every goroutine tries to move from the same version,
+	//only one of them may win
+	var wg sync.WaitGroup
+	wins := make(chan Point, 9)
+	for x := 0; x < 9; x++ {
+		wg.Add(1)
+		go func(x int) {
+			defer wg.Done()
+			if _, _, err := s.Play(v, true, x, 0); err == nil {
+				wins <- Point{x, 0}
+			}
+			s.View(func(b *Board) {
+				b.Score()
+				b.ScoreArea([]Point{{x, 0}})
+				b.LegalMoves(false)
+			})
+		}(x)
+	}
+	wg.Wait()
+	close(wins)
+	if len(wins) != 1 || s.Version() != v+1 {
+		t.Error("Expected exactly one move, got", len(wins), "at version", s.Version())
+	}
+}
@@ -38,11 +38,11 @@ func (b *Board) PrettySVG() (svg string) {
 	//Place pieces
 	for y := 0; y < b.Size; y++ {
 		for x := 0; x < b.Size; x++ {
-			if b.Grid[y][x].Black {
+			if c := b.cells[y*b.Size+x]; c == blackStone {
 				svg += "<use x=" + strconv.Itoa(x*scale) + " y=" + strconv.Itoa(y*scale) +
 					" width=" + strconv.Itoa(scale) + " height=" + strconv.Itoa(scale) +
 					" xlink:href=\"#blackstone\" />\n"
-			} else if b.Grid[y][x].White {
+			} else if c == whiteStone {
 				svg += "<use x=" + strconv.Itoa(x*scale) + " y=" + strconv.Itoa(y*scale) +
 					" width=" + strconv.Itoa(scale) + " height=" + strconv.Itoa(scale) +
 					" xlink:href=\"#whitestone\" />\n"
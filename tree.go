@@ -0,0 +1,211 @@
+package baduk
+
+//A Node of an SGF game tree. Props holds its properties
+//in the order they were read. The first of Children
+//continues the main line, the others are variations.
+type Node struct {
+	Props    []Property
+	Parent   *Node
+	Children []*Node
+}
+
+//A Property of an SGF Node, such as B[dd] or AB[aa][bb].
+//Values are unescaped.
+type Property struct {
+	ID     string
+	Values []string
+	//Where the identifier was read, for errors
+	line int
+	col  int
+}
+
+//Returns the Property with id, or nil
+func (n *Node) property(id string) *Property {
+	for i := range n.Props {
+		if n.Props[i].ID == id {
+			return &n.Props[i]
+		}
+	}
+	return nil
+}
+
+//Returns the values of the Property with id,
+//or nil if the Node doesn't have it
+func (n *Node) Get(id string) []string {
+	if p := n.property(id); p != nil {
+		return p.Values
+	}
+	return nil
+}
+
+//Sets the values of the Property with id,
+//adding it if needed. No values removes it.
+func (n *Node) Set(id string, values ...string) {
+	for i := range n.Props {
+		if n.Props[i].ID != id {
+			continue
+		}
+		if len(values) == 0 {
+			n.Props = append(n.Props[:i], n.Props[i+1:]...)
+		} else {
+			n.Props[i].Values = values
+		}
+		return
+	}
+	if len(values) > 0 {
+		n.Props = append(n.Props, Property{ID: id, Values: values})
+	}
+	return
+}
+
+//Adds a child Node, after any others
+func (n *Node) AddChild(child *Node) {
+	child.Parent = n
+	n.Children = append(n.Children, child)
+	return
+}
+
+//Writes the game tree from the Node down as
+//SGF, with every variation
+func (n *Node) SGF() string {
+	return "(" + n.sequence() + ")\n"
+}
+
+//Writes the Node and the line following it,
+//up to where it branches, then each branch
+func (n *Node) sequence() (sgf string) {
+	for {
+		sgf += ";"
+		for _, p := range n.Props {
+			sgf += p.ID
+			for _, v := range p.Values {
+				sgf += "[" + sgfText(v) + "]"
+			}
+		}
+		if len(n.Children) != 1 {
+			break
+		}
+		n = n.Children[0]
+	}
+	for _, c := range n.Children {
+		sgf += "\n(" + c.sequence() + ")"
+	}
+	return
+}
+
+//Returns the main line from the Node down
+func (n *Node) mainLine() (line []*Node) {
+	for ; n != nil; n = n.firstChild() {
+		line = append(line, n)
+	}
+	return
+}
+
+//Returns the first child, or nil
+func (n *Node) firstChild() *Node {
+	if len(n.Children) == 0 {
+		return nil
+	}
+	return n.Children[0]
+}
+
+//A Cursor walks an SGF game tree, keeping the
+//Game at its Node. Moving forward plays the Node's
+//move with set, moving back takes it back with Undo,
+//and anything else replays the line from the root.
+type Cursor struct {
+	root *Node
+	node *Node
+	//The Game at node, nil if it must be replayed
+	game *Game
+}
+
+//Starts the Cursor at the root of a game tree
+func (c *Cursor) Init(root *Node) {
+	c.root, c.node, c.game = root, root, nil
+	return
+}
+
+//Returns the Node the Cursor is at
+func (c *Cursor) Node() *Node {
+	return c.node
+}
+
+//Moves forward along the main line,
+//returns false at the end of the line
+func (c *Cursor) Next() bool {
+	return c.Variation(0)
+}
+
+//Moves forward to the child Node i,
+//returns false if there's no such child
+func (c *Cursor) Variation(i int) bool {
+	if i < 0 || i >= len(c.node.Children) {
+		return false
+	}
+	c.node = c.node.Children[i]
+	//An illegal move is reported by Game
+	if c.game != nil && c.game.loadNode(c.node) != nil {
+		c.game = nil
+	}
+	return true
+}
+
+//Moves back to the parent Node,
+//returns false at the root
+func (c *Cursor) Prev() bool {
+	if c.node.Parent == nil {
+		return false
+	}
+	//Only a plain move can be taken back
+	switch {
+	case c.game == nil:
+	case c.node.property("AB") != nil, c.node.property("AW") != nil,
+		c.node.property("AE") != nil, c.node.property("PL") != nil:
+		c.game = nil
+	case c.node.property("B") != nil, c.node.property("W") != nil:
+		if c.game.Undo() != nil {
+			c.game = nil
+		}
+	}
+	c.node = c.node.Parent
+	return true
+}
+
+//Moves back to the root
+func (c *Cursor) Root() {
+	c.node, c.game = c.root, nil
+	return
+}
+
+//Returns the Game at the Cursor's Node, with the
+//moves of the line from the root. The Game belongs
+//to the Cursor and changes as it moves, so don't
+//change it. A malformed Node or an illegal move on
+//the line returns an *SGFError.
+func (c *Cursor) Game() (g *Game, err error) {
+	if c.game == nil {
+		var line []*Node
+		for n := c.node; n != nil; n = n.Parent {
+			line = append([]*Node{n}, line...)
+		}
+		g = new(Game)
+		if err = g.loadLine(line); err != nil {
+			return nil, err
+		}
+		c.game = g
+	}
+	g = c.game
+	return
+}
+
+//Returns a copy of the Board at the Cursor's Node,
+//as Game does
+func (c *Cursor) Board() (b *Board, err error) {
+	g, err := c.Game()
+	if err != nil {
+		return
+	}
+	b = g.Board.Clone()
+	return
+}
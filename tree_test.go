@@ -0,0 +1,88 @@
+package baduk
+
+import (
+	"errors"
+	"testing"
+)
+
+const review = `(;GM[1]SZ[5]C[A review]
+;B[cc]C[Center];W[bc]
+(;B[cb];W[db])
+(;B[bb]C[Better\]?];W[cd])
+(;B[cc]))`
+
+func TestParseSGFTree(t *testing.T) {
+	trees, err := ParseSGF(review)
+	if err != nil || len(trees) != 1 {
+		t.Fatal("Error parsing SGF:", err)
+	}
+	root := trees[0]
+	//The third node branches into three variations
+	n := root.Children[0].Children[0]
+	if len(n.Children) != 3 || n.Children[1].Parent != n {
+		t.Fatal("Expected three variations")
+	}
+	if c := n.Children[1].Get("C"); len(c) != 1 || c[0] != "Better]?" {
+		t.Error("Expected comment, got", c)
+	}
+	want := "(;GM[1]SZ[5]C[A review];B[cc]C[Center];W[bc]\n(;B[cb];W[db])\n(;B[bb]C[Better\\]?];W[cd])\n(;B[cc]))\n"
+	if got := root.SGF(); got != want {
+		t.Error("Expected", want, "got", got)
+	}
+	//Round trip
+	again, err := ParseSGF(root.SGF())
+	if err != nil || again[0].SGF() != want {
+		t.Error("Expected the tree to round trip, got", err)
+	}
+	//Setting and removing properties
+	root.Set("PB", "Ann")
+	root.Set("C")
+	root.Set("SZ", "9")
+	if root.Get("PB")[0] != "Ann" || root.Get("C") != nil || root.Get("SZ")[0] != "9" || len(root.Props) != 3 {
+		t.Error("Expected PB set, C removed and SZ changed, got", root.Props)
+	}
+}
+
+func TestCursor(t *testing.T) {
+	trees, _ := ParseSGF(review)
+	var c Cursor
+	c.Init(trees[0])
+	for c.Next() {
+	}
+	b, err := c.Board()
+	if err != nil || b.MoveNumber() != 4 || b.cells[1*5+3] != whiteStone {
+		t.Fatal("Expected the end of the main line, got", err)
+	}
+	//Back up and take the second variation
+	c.Prev()
+	c.Prev()
+	if !c.Variation(1) || c.Node().Get("C")[0] != "Better]?" || !c.Next() {
+		t.Fatal("Expected the second variation")
+	}
+	b, _ = c.Board()
+	var want Board
+	want.LoadSGF("(;SZ[5];B[cc];W[bc];B[bb];W[cd])")
+	if b.SGF() != want.SGF() {
+		t.Error("Expected", want.PrettyString(), "got", b.PrettyString())
+	}
+	if c.Next() || c.Variation(5) {
+		t.Error("Expected the end of the variation")
+	}
+	//The third variation replays an illegal move
+	c.Prev()
+	c.Prev()
+	c.Variation(2)
+	var se *SGFError
+	if _, err = c.Game(); !errors.As(err, &se) || !errors.Is(err, ErrOccupied) || se.Line != 5 {
+		t.Error("Expected ErrOccupied on line 5, got", err)
+	}
+	//Going back recovers
+	c.Prev()
+	if g, err := c.Game(); err != nil || len(g.Moves) != 2 {
+		t.Error("Expected two moves, got", err)
+	}
+	c.Root()
+	if c.Prev() || c.Node() != trees[0] {
+		t.Error("Expected to stay at the root")
+	}
+}
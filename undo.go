@@ -0,0 +1,125 @@
+package baduk
+
+import "errors"
+
+//ErrNoUndo is returned when there's no move to undo
+var ErrNoUndo = errors.New("Nothing to undo")
+
+//ErrNoRedo is returned when there's no move to redo
+var ErrNoRedo = errors.New("Nothing to redo")
+
+//A record holds what's needed to undo a move:
+//where it was played, the stones it removed,
+//and the ko state from before the move
+type record struct {
+	pass     bool
+	black    bool
+	at       Point
+	captured []Point
+	suicided []Point
+	ko       *Point
+	koBlack  bool
+}
+
+//Returns the number of moves made on the Board,
+//including passes, since Init or Decode
+func (b *Board) MoveNumber() int {
+	return len(b.undo)
+}
+
+//Takes back the last move on the Board, restoring
+//captured chains, prisoners, the ko and the hash
+func (b *Board) Undo() (err error) {
+	if len(b.undo) == 0 {
+		err = ErrNoUndo
+		return
+	}
+	r := b.undo[len(b.undo)-1]
+	b.undo = b.undo[:len(b.undo)-1]
+	if !r.pass {
+		//The suicided stones include the one played
+		b.fill(r.captured, !r.black)
+		b.fill(r.suicided, r.black)
+		b.setE(r.at.X, r.at.Y)
+		if r.black {
+			b.blackCaptures -= len(r.captured)
+			b.whiteCaptures -= len(r.suicided)
+		} else {
+			b.whiteCaptures -= len(r.captured)
+			b.blackCaptures -= len(r.suicided)
+		}
+	}
+	b.ko, b.koBlack = r.ko, r.koBlack
+	b.history = b.history[:len(b.history)-1]
+	b.hash = b.history[len(b.history)-1].hash
+	b.redo = append(b.redo, r)
+	return
+}
+
+//Plays again the last move taken back by Undo
+func (b *Board) Redo() (err error) {
+	if len(b.redo) == 0 {
+		err = ErrNoRedo
+		return
+	}
+	r := b.redo[len(b.redo)-1]
+	//Playing clears the redo stack, so keep the rest
+	redo := b.redo[:len(b.redo)-1]
+	if r.pass {
+		b.pass(r.black)
+	} else if _, err = b.set(r.at.X, r.at.Y, r.black); err != nil {
+		return
+	}
+	b.redo = redo
+	return
+}
+
+//Takes back the last move of the Game,
+//handicap stones can't be taken back
+func (g *Game) Undo() (err error) {
+	if len(g.Moves) == 0 {
+		err = ErrNoUndo
+		return
+	}
+	if err = g.Board.Undo(); err != nil {
+		return
+	}
+	m := g.Moves[len(g.Moves)-1]
+	g.Moves = g.Moves[:len(g.Moves)-1]
+	g.undone = append(g.undone, m)
+	g.whiteToPlay = !m.Black
+	g.countPasses()
+	return
+}
+
+//Plays again the last move of the Game
+//taken back by Undo
+func (g *Game) Redo() (err error) {
+	if len(g.undone) == 0 {
+		err = ErrNoRedo
+		return
+	}
+	if err = g.Board.Redo(); err != nil {
+		return
+	}
+	m := g.undone[len(g.undone)-1]
+	g.undone = g.undone[:len(g.undone)-1]
+	g.Moves = append(g.Moves, m)
+	g.whiteToPlay = m.Black
+	g.countPasses()
+	return
+}
+
+//Counts the passes at the end of the Game,
+//and clears dead stones if it's no longer over
+func (g *Game) countPasses() {
+	g.passes = 0
+	for i := len(g.Moves) - 1; i >= 0 && g.Moves[i].Pass; i-- {
+		g.passes++
+	}
+	if !g.Over() {
+		g.dead = nil
+		g.agreed = [2]bool{}
+	}
+	return
+}
@@ -0,0 +1,111 @@
+package baduk
+
+import "testing"
+
+func TestUndo(t *testing.T) {
+	var b Board
+	b.Init(5)
+	if err := b.Undo(); err != ErrNoUndo {
+		t.Error("Expected ErrNoUndo, got", err)
+	}
+	setupKo(&b)
+	before, _ := b.Encode()
+	hash := b.hash
+	b.SetB(2, 1)
+	if b.ko == nil || b.MoveNumber() != 8 {
+		t.Error("Expected ko after capture at move 8, got", b.ko, b.MoveNumber())
+	}
+	//Undo restores the captured stone, hash and prisoners
+	if err := b.Undo(); err != nil {
+		t.Error("Error undoing:", err)
+	}
+	after, _ := b.Encode()
+	if after != before || b.hash != hash || b.ko != nil || b.MoveNumber() != 7 {
+		t.Error("Expected board restored after undo")
+		t.Log(b.PrettyString())
+	}
+	if black, _ := b.Captures(); black != 0 {
+		t.Error("Expected no black captures after undo, got", black)
+	}
+	//Redo plays the capture again
+	if err := b.Redo(); err != nil {
+		t.Error("Error redoing:", err)
+	}
+	if !b.Grid()[1][1].Empty || b.ko == nil || b.MoveNumber() != 8 {
+		t.Error("Expected capture and ko after redo")
+		t.Log(b.PrettyString())
+	}
+	if err := b.Redo(); err != ErrNoRedo {
+		t.Error("Expected ErrNoRedo, got", err)
+	}
+	//Undoing a pass restores the ko it cleared
+	b.pass(false)
+	b.Undo()
+	if b.ko == nil || *b.ko != (Point{1, 1}) {
+		t.Error("Expected ko at 1,1 after undoing pass, got", b.ko)
+	}
+	if err := b.SetW(1, 1); err != ErrKo {
+		t.Error("Expected ErrKo after undoing pass, got", err)
+	}
+	//A new move clears the redo stack
+	b.Undo()
+	b.SetB(4, 4)
+	if err := b.Redo(); err != ErrNoRedo {
+		t.Error("Expected ErrNoRedo after new move, got", err)
+	}
+}
+
+func TestUndoSuicide(t *testing.T) {
+	var b Board
+	b.Init(5)
+	b.AllowSuicide = true
+	b.SetB(0, 0)
+	b.SetW(0, 1)
+	b.SetW(1, 1)
+	b.SetW(2, 0)
+	b.SetB(1, 0)
+	b.Undo()
+	if !b.Grid()[0][0].Black || !b.Grid()[0][1].Empty {
+		t.Error("Expected suicided stone restored, played stone removed")
+		t.Log(b.PrettyString())
+	}
+	if _, white := b.Captures(); white != 0 {
+		t.Error("Expected no white captures after undo, got", white)
+	}
+}
+
+func TestGameUndo(t *testing.T) {
+	var g Game
+	g.Init(9)
+	g.Play(4, 4)
+	g.Play(3, 3)
+	g.Pass()
+	g.Pass()
+	g.ToggleDead(3, 3)
+	if err := g.Undo(); err != nil {
+		t.Error("Error undoing:", err)
+	}
+	if g.Over() || len(g.Dead()) != 0 || g.BlackToPlay() {
+		t.Error("Expected game to resume with White to play and no dead stones")
+	}
+	g.Undo()
+	g.Undo()
+	if len(g.Moves) != 1 || !g.Board.Grid()[3][3].Empty || g.BlackToPlay() {
+		t.Error("Expected one move left with White to play, got", g.Moves)
+	}
+	g.Redo()
+	g.Redo()
+	g.Redo()
+	if !g.Over() || !g.Board.Grid()[3][3].White || len(g.Moves) != 4 {
+		t.Error("Expected game over again after redoing, got", g.Moves)
+	}
+	if err := g.Redo(); err != ErrNoRedo {
+		t.Error("Expected ErrNoRedo, got", err)
+	}
+	//Handicap stones can't be taken back
+	g.Init(9)
+	g.SetHandicap(2)
+	if err := g.Undo(); err != ErrNoUndo {
+		t.Error("Expected ErrNoUndo after handicap, got", err)
+	}
+}
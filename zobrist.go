@@ -0,0 +1,86 @@
+package baduk
+
+//A KoRule determines which repeated
+//positions a move is forbidden to create
+type KoRule int
+
+const (
+	//SimpleKo only forbids immediately
+	//retaking a single stone ko
+	SimpleKo KoRule = iota
+	//PositionalSuperko forbids recreating
+	//any prior position on the Board
+	PositionalSuperko
+	//SituationalSuperko forbids recreating a prior
+	//position with the same player to move
+	SituationalSuperko
+)
+
+//A situation is an entry in the position history,
+//the Zobrist hash of the Board and who plays next
+type situation struct {
+	hash        uint64
+	blackToPlay bool
+}
+
+//Random bitstrings for each color at each Point,
+//indexed by [white][y*19+x]
+var zobrist [2][19 * 19]uint64
+
+//Fills the Zobrist table with a fixed pseudorandom
+//sequence (splitmix64) so hashes are reproducible
+func init() {
+	seed := uint64(0x9e3779b97f4a7c15)
+	for c := range zobrist {
+		for i := range zobrist[c] {
+			seed += 0x9e3779b97f4a7c15
+			z := seed
+			z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+			z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+			zobrist[c][i] = z ^ (z >> 31)
+		}
+	}
+}
+
+//Returns the Zobrist bitstring for a stone at x, y
+func zobristKey(x, y int, isBlack bool) uint64 {
+	if isBlack {
+		return zobrist[0][y*19+x]
+	}
+	return zobrist[1][y*19+x]
+}
+
+//Computes the hash of the Board from scratch
+//and restarts the position history with it,
+//with the given color to play next
+func (b *Board) resetHistory(blackToPlay bool) {
+	b.hash = 0
+	for i, c := range b.cells {
+		if c != empty {
+			p := b.point(i)
+			b.hash ^= zobristKey(p.X, p.Y, c == blackStone)
+		}
+	}
+	b.history = []situation{{b.hash, blackToPlay}}
+	return
+}
+
+//Returns true if a position with hash, with
+//blackToPlay next, is forbidden by Board.KoRule
+func (b *Board) repeats(hash uint64, blackToPlay bool) bool {
+	switch b.KoRule {
+	case PositionalSuperko:
+		for _, s := range b.history {
+			if s.hash == hash {
+				return true
+			}
+		}
+	case SituationalSuperko:
+		for _, s := range b.history {
+			if s.hash == hash && s.blackToPlay == blackToPlay {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package baduk
+
+import "testing"
+
+//Sets up a ko with black to take at 2,1,
+//placing black's 1,2 stone last
+func setupKo(b *Board) {
+	b.SetW(2, 0)
+	b.SetW(3, 1)
+	b.SetW(2, 2)
+	b.SetW(1, 1)
+	b.SetB(1, 0)
+	b.SetB(0, 1)
+	b.SetB(1, 2)
+	return
+}
+
+func TestHash(t *testing.T) {
+	var b Board
+	b.Init(5)
+	if b.hash != 0 {
+		t.Error("Expected zero hash for empty board, got", b.hash)
+	}
+	setupKo(&b)
+	b.SetB(2, 1)
+	hash := b.hash
+	b.resetHistory(true)
+	if b.hash != hash {
+		t.Error("Expected incremental hash", hash, "to match computed hash", b.hash)
+	}
+	//Decoding reproduces the hash
+	enc, _ := b.Encode()
+	var c Board
+	c.Decode(enc)
+	if c.hash != hash {
+		t.Error("Expected decoded hash", c.hash, "to match", hash)
+	}
+}
+
+func TestSuperko(t *testing.T) {
+	var b Board
+	//Simple ko allows retaking after both pass
+	b.Init(5)
+	setupKo(&b)
+	b.SetB(2, 1)
+	b.pass(false)
+	b.pass(true)
+	if err := b.SetW(1, 1); err != nil {
+		t.Error("Expected retake to be legal under simple ko, got", err)
+	}
+	//Positional superko forbids recreating the earlier position
+	b.KoRule = PositionalSuperko
+	b.Init(5)
+	setupKo(&b)
+	b.SetB(2, 1)
+	b.pass(false)
+	b.pass(true)
+	if err := b.SetW(1, 1); err != ErrSuperko {
+		t.Error("Expected ErrSuperko, got", err)
+	}
+	if !b.Grid()[1][1].Empty || !b.Grid()[1][2].Black {
+		t.Error("Expected board unchanged after superko violation")
+		t.Log(b.PrettyString())
+	}
+	//Situational superko allows it, since the earlier
+	//position had white to play, not black
+	b.KoRule = SituationalSuperko
+	b.Init(5)
+	setupKo(&b)
+	b.SetB(2, 1)
+	b.pass(false)
+	b.pass(true)
+	if err := b.SetW(1, 1); err != nil {
+		t.Error("Expected retake to be legal under situational superko, got", err)
+	}
+	//...but forbids it when the earlier position had black to play
+	b.Init(5)
+	setupKo(&b)
+	b.SetW(4, 4)
+	b.SetB(2, 1)
+	b.pass(false)
+	b.pass(true)
+	if err := b.SetW(1, 1); err != ErrSuperko {
+		t.Error("Expected ErrSuperko, got", err)
+	}
+}
+
+//Sets up three kos on a 9x9 Board, in rows 0-2, 3-5
+//and 6-8, each taken by black at 2,y and white at 1,y.
+//White holds the top and bottom kos, black the middle,
+//with black to play.
+func setupTripleKo(b *Board) {
+	for oy := 0; oy < 9; oy += 3 {
+		b.SetB(1, oy)
+		b.SetB(0, oy+1)
+		b.SetB(1, oy+2)
+		b.SetW(2, oy)
+		b.SetW(3, oy+1)
+		b.SetW(2, oy+2)
+	}
+	b.SetW(1, 1)
+	b.SetB(2, 4)
+	b.SetW(1, 7)
+	b.resetHistory(true)
+	return
+}
+
+func TestTripleKo(t *testing.T) {
+	for _, rule := range []KoRule{SimpleKo, PositionalSuperko, SituationalSuperko} {
+		var b Board
+		b.Init(9)
+		b.KoRule = rule
+		setupTripleKo(&b)
+		start := b.PrettyString()
+		//Each color takes a ko in turn, until
+		//white's sixth capture would recreate the start
+		if b.SetB(2, 1) != nil || b.SetW(1, 4) != nil || b.SetB(2, 7) != nil ||
+			b.SetW(1, 1) != nil || b.SetB(2, 4) != nil {
+			t.Fatal("Expected the kos to be taken under", rule)
+		}
+		before, hash := b.PrettyString(), b.hash
+		err := b.SetW(1, 7)
+		if rule == SimpleKo {
+			if err != nil || b.PrettyString() != start {
+				t.Error("Expected simple ko to allow the cycle, got", err)
+			}
+			continue
+		}
+		if err != ErrSuperko {
+			t.Error("Expected ErrSuperko under", rule, "got", err)
+		}
+		if b.PrettyString() != before || b.hash != hash || !b.Grid()[7][2].Black {
+			t.Error("Expected board unchanged after superko violation under", rule)
+			t.Log(b.PrettyString())
+		}
+	}
+}